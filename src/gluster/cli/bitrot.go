@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"encoding/xml"
+)
+
+// Allowed values of scrub-throttle and scrub-frequency
+var (
+	ScrubThrottles   = []string{"lazy", "normal", "aggressive"}
+	ScrubFrequencies = []string{"hourly", "daily", "weekly", "biweekly", "monthly"}
+)
+
+// ScrubNode object, scrubber status of a node
+type ScrubNode struct {
+	Name              string   `xml:"node_name" json:"name"`
+	ScrubbedFiles     int64    `xml:"scrubbed_files" json:"scrubbed_files"`
+	UnsignedFiles     int64    `xml:"unsigned_files" json:"unsigned_files"`
+	LastScrubTime     string   `xml:"last_scrub_time" json:"last_scrub_time"`
+	LastScrubDuration string   `xml:"last_scrub_duration" json:"last_scrub_duration"`
+	ErrorCount        int64    `xml:"error_count" json:"error_count"`
+	CorruptedObjects  []string `xml:"bad_file>gfid" json:"corrupted_objects"`
+}
+
+// ScrubStatus from Gluster bitrot scrub status output
+type ScrubStatus struct {
+	XMLName       xml.Name    `xml:"cliOutput" json:"-"`
+	Volume        string      `xml:"volBitrot>volume_name" json:"volume"`
+	State         string      `xml:"volBitrot>state" json:"state"`
+	Throttle      string      `xml:"volBitrot>scrub_impact" json:"scrub_throttle"`
+	Frequency     string      `xml:"volBitrot>scrub_frequency" json:"scrub_frequency"`
+	BitrotLogFile string      `xml:"volBitrot>bitrot_log_file" json:"bitrot_log_file"`
+	ScrubLogFile  string      `xml:"volBitrot>scrubber_log_file" json:"scrubber_log_file"`
+	Nodes         []ScrubNode `xml:"volBitrot>node" json:"nodes"`
+}
+
+// ScrubOptions - Options to configure the scrubber
+type ScrubOptions struct {
+	Throttle  string `json:"throttle"`
+	Frequency string `json:"frequency"`
+}
+
+func bitrotCmd(volname string, args ...string) []string {
+	// volume bitrot <VOLNAME> {enable | disable} | scrub-throttle
+	// {lazy|normal|aggressive} | scrub-frequency {hourly|daily|weekly|
+	// biweekly|monthly} | scrub {pause|resume|status|ondemand}
+	cmd := []string{"volume", "bitrot", volname}
+	return append(cmd, args...)
+}
+
+// VolumeBitrotEnable is a func to enable bitrot detection of a Volume
+func VolumeBitrotEnable(volname string) error {
+	return ExecuteCmd(bitrotCmd(volname, "enable"))
+}
+
+// VolumeBitrotDisable is a func to disable bitrot detection of a Volume
+func VolumeBitrotDisable(volname string) error {
+	return ExecuteCmd(bitrotCmd(volname, "disable"))
+}
+
+// VolumeScrubThrottle is a func to set the scrubber throttle
+func VolumeScrubThrottle(volname string, throttle string) error {
+	return ExecuteCmd(bitrotCmd(volname, "scrub-throttle", throttle))
+}
+
+// VolumeScrubFrequency is a func to set the scrubber frequency
+func VolumeScrubFrequency(volname string, frequency string) error {
+	return ExecuteCmd(bitrotCmd(volname, "scrub-frequency", frequency))
+}
+
+// VolumeScrubPause is a func to pause the scrubber
+func VolumeScrubPause(volname string) error {
+	return ExecuteCmd(bitrotCmd(volname, "scrub", "pause"))
+}
+
+// VolumeScrubResume is a func to resume the scrubber
+func VolumeScrubResume(volname string) error {
+	return ExecuteCmd(bitrotCmd(volname, "scrub", "resume"))
+}
+
+// VolumeScrubStatus is a func to get the scrubber status of all the
+// nodes of a Volume, including the list of corrupted objects
+func VolumeScrubStatus(volname string) (ScrubStatus, error) {
+	var q ScrubStatus
+	data, err := ExecuteCmdXML(bitrotCmd(volname, "scrub", "status"))
+	if err != nil {
+		return ScrubStatus{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return ScrubStatus{}, xmlerr
+	}
+	return q, nil
+}
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// AddBrickOptions - Options to add Bricks to a Volume
+type AddBrickOptions struct {
+	Bricks        []string `json:"bricks"`
+	ReplicaCount  int      `json:"replica"`
+	ArbiterCount  int      `json:"arbiter"`
+	DisperseCount int      `json:"disperse"`
+	Force         bool     `json:"force"`
+}
+
+// RemoveBrickOptions - Options to remove Bricks from a Volume
+type RemoveBrickOptions struct {
+	Bricks       []string `json:"bricks"`
+	ReplicaCount int      `json:"replica"`
+	Force        bool     `json:"force"`
+}
+
+// RemoveBrickStatus from Gluster remove-brick status output
+type RemoveBrickStatus struct {
+	XMLName   xml.Name        `xml:"cliOutput" json:"-"`
+	TaskID    string          `xml:"volRemoveBrick>task-id" json:"task_id"`
+	Nodes     []RebalanceNode `xml:"volRemoveBrick>node" json:"nodes"`
+	Aggregate RebalanceNode   `xml:"volRemoveBrick>aggregate" json:"aggregate"`
+}
+
+// ReplaceBrickOptions - Options to replace a Brick of a Volume
+type ReplaceBrickOptions struct {
+	Destination string `json:"destination"`
+}
+
+// ResetBrickOptions - Options to reset a Brick of a Volume. Reset is
+// started when Commit is not set, Destination defaults to the source
+// brick if not specified during commit.
+type ResetBrickOptions struct {
+	Destination string `json:"destination"`
+	Commit      bool   `json:"commit"`
+	Force       bool   `json:"force"`
+}
+
+// VolumeAddBrick is a func to add bricks to a Gluster Volume. Replica
+// and Arbiter counts are required only if the Volume's replica count
+// changes. DisperseCount is not passed to glusterd since disperse count
+// of a Volume can't be changed by adding bricks.
+func VolumeAddBrick(volname string, bricks []string, options AddBrickOptions) error {
+	// volume add-brick <VOLNAME> [<stripe|replica> <COUNT> [arbiter <COUNT>]]
+	// <NEW-BRICK> ... [force] - add brick to volume <VOLNAME>
+	cmd := []string{"volume", "add-brick", volname}
+	if options.ReplicaCount != 0 {
+		cmd = append(cmd, "replica", fmt.Sprintf("%d", options.ReplicaCount))
+	}
+	if options.ArbiterCount != 0 {
+		cmd = append(cmd, "arbiter", fmt.Sprintf("%d", options.ArbiterCount))
+	}
+
+	cmd = append(cmd, bricks...)
+
+	if options.Force {
+		cmd = append(cmd, "force")
+	}
+	return ExecuteCmd(cmd)
+}
+
+func removeBrickCmd(volname string, bricks []string, replica int, action string) []string {
+	// volume remove-brick <VOLNAME> [replica <COUNT>] <BRICK> ...
+	// <start|stop|status|commit|force>
+	cmd := []string{"volume", "remove-brick", volname}
+	if replica != 0 {
+		cmd = append(cmd, "replica", fmt.Sprintf("%d", replica))
+	}
+	cmd = append(cmd, bricks...)
+	return append(cmd, action)
+}
+
+// VolumeRemoveBrickStart is a func to start migrating data from the
+// bricks which are being removed. If Force is set, bricks are removed
+// immediately without migrating the data.
+func VolumeRemoveBrickStart(volname string, bricks []string, options RemoveBrickOptions) error {
+	action := "start"
+	if options.Force {
+		action = "force"
+	}
+	return ExecuteCmd(removeBrickCmd(volname, bricks, options.ReplicaCount, action))
+}
+
+// VolumeRemoveBrickStop is a func to stop the data migration started
+// by remove-brick start, bricks will remain part of the Volume
+func VolumeRemoveBrickStop(volname string, bricks []string) error {
+	return ExecuteCmd(removeBrickCmd(volname, bricks, 0, "stop"))
+}
+
+// VolumeRemoveBrickCommit is a func to remove the bricks from the Volume
+// once data migration is complete
+func VolumeRemoveBrickCommit(volname string, bricks []string, options RemoveBrickOptions) error {
+	return ExecuteCmd(removeBrickCmd(volname, bricks, options.ReplicaCount, "commit"))
+}
+
+// VolumeRemoveBrickStatus is a func to get the data migration progress
+// of all the nodes for the bricks which are being removed
+func VolumeRemoveBrickStatus(volname string, bricks []string) (RemoveBrickStatus, error) {
+	var q RemoveBrickStatus
+	data, err := ExecuteCmdXML(removeBrickCmd(volname, bricks, 0, "status"))
+	if err != nil {
+		return RemoveBrickStatus{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return RemoveBrickStatus{}, xmlerr
+	}
+	return q, nil
+}
+
+// VolumeReplaceBrick is a func to replace a brick of a Gluster Volume
+// with new brick. Self heal will populate the data to new brick.
+func VolumeReplaceBrick(volname string, source string, destination string) error {
+	// volume replace-brick <VOLNAME> <SOURCE-BRICK> <NEW-BRICK> commit force
+	cmd := []string{"volume", "replace-brick", volname, source, destination, "commit", "force"}
+	return ExecuteCmd(cmd)
+}
+
+// VolumeResetBrick is a func to reset a brick of a Gluster Volume. Reset
+// start takes the brick offline, commit brings it back once the disk
+// is replaced.
+func VolumeResetBrick(volname string, source string, options ResetBrickOptions) error {
+	// volume reset-brick <VOLNAME> <SOURCE-BRICK> {{start} |
+	// {<NEW-BRICK> commit}}
+	cmd := []string{"volume", "reset-brick", volname, source}
+	if !options.Commit {
+		cmd = append(cmd, "start")
+		return ExecuteCmd(cmd)
+	}
+
+	destination := options.Destination
+	if destination == "" {
+		destination = source
+	}
+	cmd = append(cmd, destination, "commit")
+	if options.Force {
+		cmd = append(cmd, "force")
+	}
+	return ExecuteCmd(cmd)
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// OpVersion - Current and maximum supported op-version of the Cluster
+type OpVersion struct {
+	Current int `json:"current"`
+	Max     int `json:"max"`
+}
+
+// ClusterOptGet is a func to get the Cluster wide options, if key is
+// empty then all the global options are returned
+func ClusterOptGet(key string) ([]VolumeOption, error) {
+	if key == "" {
+		key = "all"
+	}
+	return VolumeOptGet("all", key)
+}
+
+// ClusterOptSet is a func to set the Cluster wide option
+func ClusterOptSet(key string, value string) error {
+	cmd := []string{"volume", "set", "all", key, value}
+	return ExecuteCmd(cmd)
+}
+
+// ClusterOptReset is a func to reset the Cluster wide option
+func ClusterOptReset(key string) error {
+	cmd := []string{"volume", "reset", "all", key}
+	return ExecuteCmd(cmd)
+}
+
+func clusterOptInt(key string) (int, error) {
+	opts, err := ClusterOptGet(key)
+	if err != nil {
+		return 0, err
+	}
+	if len(opts) == 0 {
+		return 0, fmt.Errorf("Option %s not found", key)
+	}
+	return strconv.Atoi(opts[0].Value)
+}
+
+// ClusterOpVersion is a func to get the current op-version and the
+// maximum op-version supported by all the nodes of Cluster
+func ClusterOpVersion() (OpVersion, error) {
+	current, err := clusterOptInt("cluster.op-version")
+	if err != nil {
+		return OpVersion{}, err
+	}
+	max, err := clusterOptInt("cluster.max-op-version")
+	if err != nil {
+		return OpVersion{}, err
+	}
+	return OpVersion{Current: current, Max: max}, nil
+}
+
+// ClusterOpVersionSet is a func to bump the op-version of Cluster
+func ClusterOpVersionSet(version int) error {
+	return ClusterOptSet("cluster.op-version", strconv.Itoa(version))
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"encoding/xml"
+)
+
+// GeorepWorker object, geo-replication worker status of a Brick
+type GeorepWorker struct {
+	MasterNode               string `xml:"master_node" json:"master_node"`
+	MasterNodeUUID           string `xml:"master_node_uuid" json:"master_node_id"`
+	MasterBrick              string `xml:"master_brick" json:"master_brick"`
+	RemoteUser               string `xml:"slave_user" json:"remote_user"`
+	Remote                   string `xml:"slave" json:"remote"`
+	RemoteNode               string `xml:"slave_node" json:"remote_node"`
+	Status                   string `xml:"status" json:"status"`
+	CrawlStatus              string `xml:"crawl_status" json:"crawl_status"`
+	LastSynced               string `xml:"last_synced" json:"last_synced"`
+	Entry                    string `xml:"entry" json:"entry"`
+	Data                     string `xml:"data" json:"data"`
+	Meta                     string `xml:"meta" json:"meta"`
+	Failures                 string `xml:"failures" json:"failures"`
+	CheckpointTime           string `xml:"checkpoint_time" json:"checkpoint_time"`
+	CheckpointCompleted      string `xml:"checkpoint_completed" json:"checkpoint_completed"`
+	CheckpointCompletionTime string `xml:"checkpoint_completion_time" json:"checkpoint_completion_time"`
+}
+
+// GeorepSession object, geo-replication session of a Volume
+type GeorepSession struct {
+	Remote  string         `xml:"session_slave" json:"remote"`
+	Workers []GeorepWorker `xml:"pair" json:"workers"`
+}
+
+// GeorepStatus from Gluster geo-replication status output
+type GeorepStatus struct {
+	XMLName  xml.Name        `xml:"cliOutput"`
+	Sessions []GeorepSession `xml:"geoRep>volume>sessions>session"`
+}
+
+type georepConfigItem struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type georepConfig struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	Config  struct {
+		Items []georepConfigItem `xml:",any"`
+	} `xml:"geoRep>config"`
+}
+
+// GeorepCreateOptions - Options to create geo-replication session
+type GeorepCreateOptions struct {
+	PushPem bool `json:"push_pem"`
+	Force   bool `json:"force"`
+}
+
+func georepCmd(mastervol string, remoteHost string, remoteVol string, args ...string) []string {
+	// volume geo-replication <MASTER_VOL> <SLAVE_HOST>::<SLAVE_VOL> ...
+	cmd := []string{"volume", "geo-replication", mastervol, remoteHost + "::" + remoteVol}
+	return append(cmd, args...)
+}
+
+func georepCmdForce(action string, force bool) []string {
+	if force {
+		return []string{action, "force"}
+	}
+	return []string{action}
+}
+
+// GeorepCreate is a func to create geo-replication session between the
+// Volume and remote Volume
+func GeorepCreate(mastervol string, remoteHost string, remoteVol string, options GeorepCreateOptions) error {
+	args := []string{"create"}
+	if options.PushPem {
+		args = append(args, "push-pem")
+	}
+	if options.Force {
+		args = append(args, "force")
+	}
+	return ExecuteCmd(georepCmd(mastervol, remoteHost, remoteVol, args...))
+}
+
+// GeorepStart is a func to start geo-replication session
+func GeorepStart(mastervol string, remoteHost string, remoteVol string, force bool) error {
+	return ExecuteCmd(georepCmd(mastervol, remoteHost, remoteVol, georepCmdForce("start", force)...))
+}
+
+// GeorepStop is a func to stop geo-replication session
+func GeorepStop(mastervol string, remoteHost string, remoteVol string, force bool) error {
+	return ExecuteCmd(georepCmd(mastervol, remoteHost, remoteVol, georepCmdForce("stop", force)...))
+}
+
+// GeorepPause is a func to pause geo-replication session
+func GeorepPause(mastervol string, remoteHost string, remoteVol string, force bool) error {
+	return ExecuteCmd(georepCmd(mastervol, remoteHost, remoteVol, georepCmdForce("pause", force)...))
+}
+
+// GeorepResume is a func to resume geo-replication session
+func GeorepResume(mastervol string, remoteHost string, remoteVol string, force bool) error {
+	return ExecuteCmd(georepCmd(mastervol, remoteHost, remoteVol, georepCmdForce("resume", force)...))
+}
+
+// GeorepDelete is a func to delete geo-replication session. Sync time is
+// reset if resetSyncTime is set, so that next session will sync all
+// the data again.
+func GeorepDelete(mastervol string, remoteHost string, remoteVol string, resetSyncTime bool) error {
+	args := []string{"delete"}
+	if resetSyncTime {
+		args = append(args, "reset-sync-time")
+	}
+	return ExecuteCmd(georepCmd(mastervol, remoteHost, remoteVol, args...))
+}
+
+// GeorepConfigGet is a func to get all the configurations of
+// geo-replication session
+func GeorepConfigGet(mastervol string, remoteHost string, remoteVol string) ([]VolumeOption, error) {
+	var q georepConfig
+	data, err := ExecuteCmdXML(georepCmd(mastervol, remoteHost, remoteVol, "config"))
+	if err != nil {
+		return []VolumeOption{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return []VolumeOption{}, xmlerr
+	}
+
+	opts := []VolumeOption{}
+	for _, item := range q.Config.Items {
+		opts = append(opts, VolumeOption{Name: item.XMLName.Local, Value: item.Value})
+	}
+	return opts, nil
+}
+
+// GeorepConfigSet is a func to set a configuration of geo-replication
+// session
+func GeorepConfigSet(mastervol string, remoteHost string, remoteVol string, key string, value string) error {
+	return ExecuteCmd(georepCmd(mastervol, remoteHost, remoteVol, "config", key, value))
+}
+
+// GeorepConfigReset is a func to reset a configuration of geo-replication
+// session to default value
+func GeorepConfigReset(mastervol string, remoteHost string, remoteVol string, key string) error {
+	return ExecuteCmd(georepCmd(mastervol, remoteHost, remoteVol, "config", "!"+key))
+}
+
+// GeorepStatusGet is a func to get the worker status of each Brick of
+// geo-replication session
+func GeorepStatusGet(mastervol string, remoteHost string, remoteVol string) ([]GeorepWorker, error) {
+	var q GeorepStatus
+	data, err := ExecuteCmdXML(georepCmd(mastervol, remoteHost, remoteVol, "status", "detail"))
+	if err != nil {
+		return []GeorepWorker{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return []GeorepWorker{}, xmlerr
+	}
+
+	workers := []GeorepWorker{}
+	for _, s := range q.Sessions {
+		workers = append(workers, s.Workers...)
+	}
+	return workers, nil
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"encoding/xml"
+	"errors"
+	"strconv"
+)
+
+// Split-brain resolution policies
+const (
+	SplitBrainBiggerFile  = "bigger-file"
+	SplitBrainLatestMtime = "latest-mtime"
+	SplitBrainSourceBrick = "source-brick"
+)
+
+// HealEntry object, file or directory pending heal
+type HealEntry struct {
+	GFID string `xml:"gfid,attr" json:"gfid,omitempty"`
+	Path string `xml:",chardata" json:"path"`
+}
+
+// HealBrick object, list of entries pending heal in a Brick
+type HealBrick struct {
+	Name          string      `xml:"name" json:"name"`
+	HostUUID      string      `xml:"hostUuid,attr" json:"host_id"`
+	Status        string      `xml:"status" json:"status"`
+	NumEntriesRaw string      `xml:"numberOfEntries" json:"-"`
+	NumEntries    int         `json:"num_entries"`
+	Entries       []HealEntry `xml:"file" json:"entries"`
+}
+
+// HealInfo from Gluster heal info output
+type HealInfo struct {
+	XMLName xml.Name    `xml:"cliOutput"`
+	List    []HealBrick `xml:"healInfo>bricks>brick"`
+}
+
+// HealSummaryBrick object, heal statistics of a Brick
+type HealSummaryBrick struct {
+	Name               string `xml:"name" json:"name"`
+	HostUUID           string `xml:"hostUuid,attr" json:"host_id"`
+	Status             string `xml:"status" json:"status"`
+	TotalRaw           string `xml:"totalNumberOfEntries" json:"-"`
+	HealPendingRaw     string `xml:"numberOfEntriesInHealPending" json:"-"`
+	SplitBrainRaw      string `xml:"numberOfEntriesInSplitBrain" json:"-"`
+	PossiblyHealingRaw string `xml:"numberOfEntriesPossiblyHealing" json:"-"`
+	Total              int    `json:"total"`
+	HealPending        int    `json:"heal_pending"`
+	SplitBrain         int    `json:"split_brain"`
+	PossiblyHealing    int    `json:"possibly_healing"`
+}
+
+// HealSummary from Gluster heal info summary output
+type HealSummary struct {
+	XMLName xml.Name           `xml:"cliOutput"`
+	List    []HealSummaryBrick `xml:"healInfo>bricks>brick"`
+}
+
+// SplitBrainOptions - Options to resolve split-brain. File is required
+// for bigger-file and latest-mtime policies, SourceBrick is required for
+// source-brick policy. All the files in split-brain are healed using
+// SourceBrick if File is not specified.
+type SplitBrainOptions struct {
+	Policy      string `json:"policy"`
+	File        string `json:"file"`
+	SourceBrick string `json:"source_brick"`
+}
+
+// Number of entries is reported as "-" if the brick is not connected
+func healCount(raw string) int {
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func volumeHealInfo(cmd []string) ([]HealBrick, error) {
+	var q HealInfo
+	data, err := ExecuteCmdXML(cmd)
+	if err != nil {
+		return []HealBrick{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return []HealBrick{}, xmlerr
+	}
+	for idx, b := range q.List {
+		q.List[idx].NumEntries = healCount(b.NumEntriesRaw)
+	}
+	return q.List, nil
+}
+
+// VolumeHealInfo is a func to get the list of entries pending heal in
+// each Brick of a Gluster Volume
+func VolumeHealInfo(volname string) ([]HealBrick, error) {
+	cmd := []string{"volume", "heal", volname, "info"}
+	return volumeHealInfo(cmd)
+}
+
+// VolumeHealInfoSplitBrain is a func to get the list of entries in
+// split-brain in each Brick of a Gluster Volume
+func VolumeHealInfoSplitBrain(volname string) ([]HealBrick, error) {
+	cmd := []string{"volume", "heal", volname, "info", "split-brain"}
+	return volumeHealInfo(cmd)
+}
+
+// VolumeHealSummary is a func to get the heal statistics of each Brick
+// of a Gluster Volume
+func VolumeHealSummary(volname string) ([]HealSummaryBrick, error) {
+	var q HealSummary
+	cmd := []string{"volume", "heal", volname, "info", "summary"}
+	data, err := ExecuteCmdXML(cmd)
+	if err != nil {
+		return []HealSummaryBrick{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return []HealSummaryBrick{}, xmlerr
+	}
+	for idx, b := range q.List {
+		q.List[idx].Total = healCount(b.TotalRaw)
+		q.List[idx].HealPending = healCount(b.HealPendingRaw)
+		q.List[idx].SplitBrain = healCount(b.SplitBrainRaw)
+		q.List[idx].PossiblyHealing = healCount(b.PossiblyHealingRaw)
+	}
+	return q.List, nil
+}
+
+// VolumeHeal is a func to trigger heal of a Gluster Volume. Only the
+// entries pending heal are healed(index heal) unless full is set.
+func VolumeHeal(volname string, full bool) error {
+	cmd := []string{"volume", "heal", volname}
+	if full {
+		cmd = append(cmd, "full")
+	}
+	return ExecuteCmd(cmd)
+}
+
+// VolumeHealSplitBrain is a func to resolve split-brain of a Gluster
+// Volume using the given policy
+func VolumeHealSplitBrain(volname string, options SplitBrainOptions) error {
+	// volume heal <VOLNAME> split-brain {bigger-file <FILE> |
+	// latest-mtime <FILE> | source-brick <HOSTNAME:BRICKNAME> [<FILE>]}
+	cmd := []string{"volume", "heal", volname, "split-brain", options.Policy}
+	switch options.Policy {
+	case SplitBrainBiggerFile, SplitBrainLatestMtime:
+		if options.File == "" {
+			return errors.New("File is required for " + options.Policy + " policy")
+		}
+		cmd = append(cmd, options.File)
+	case SplitBrainSourceBrick:
+		if options.SourceBrick == "" {
+			return errors.New("Source brick is required for " + options.Policy + " policy")
+		}
+		cmd = append(cmd, options.SourceBrick)
+		if options.File != "" {
+			cmd = append(cmd, options.File)
+		}
+	default:
+		return errors.New("Invalid split-brain policy: " + options.Policy)
+	}
+	return ExecuteCmd(cmd)
+}
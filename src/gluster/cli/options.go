@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"encoding/xml"
+)
+
+// Volume option types. Gluster set help output does not include the
+// type of option, so types are known only for the options listed in
+// optionTypes. Type is empty for all other options and their values
+// are validated only by glusterd
+const (
+	OptionTypeBool = "bool"
+	OptionTypeInt  = "int"
+	OptionTypeSize = "size"
+)
+
+// BoolValues - Values accepted by Gluster for boolean options
+var BoolValues = []string{"on", "off", "enable", "disable", "true", "false", "yes", "no", "1", "0"}
+
+// OptionCatalogEntry object, Volume option from Gluster set help output
+type OptionCatalogEntry struct {
+	Name        string `xml:"name" json:"name"`
+	Description string `xml:"description" json:"description"`
+	Default     string `xml:"defaultValue" json:"default"`
+	Type        string `json:"type,omitempty"`
+	Min         *int64 `json:"min,omitempty"`
+	Max         *int64 `json:"max,omitempty"`
+}
+
+// OptionCatalog from Gluster set help-xml output
+type OptionCatalog struct {
+	XMLName xml.Name             `xml:"volumeOptionsDefaults"`
+	List    []OptionCatalogEntry `xml:"option"`
+}
+
+type optionTypeInfo struct {
+	Type string
+	Min  int64
+	Max  int64
+}
+
+// optionTypes - Partial override of the catalog entries, help-xml
+// output has only the name, default value and description of options.
+// Type and range of the options listed here are taken from the option
+// definitions of the translators. Options not listed are returned
+// without type and range. Range is not set if Min and Max are zero
+var optionTypes = map[string]optionTypeInfo{
+	"performance.readdir-ahead":            {Type: OptionTypeBool},
+	"performance.quick-read":               {Type: OptionTypeBool},
+	"performance.io-cache":                 {Type: OptionTypeBool},
+	"performance.read-ahead":               {Type: OptionTypeBool},
+	"performance.write-behind":             {Type: OptionTypeBool},
+	"performance.open-behind":              {Type: OptionTypeBool},
+	"performance.stat-prefetch":            {Type: OptionTypeBool},
+	"performance.flush-behind":             {Type: OptionTypeBool},
+	"performance.strict-o-direct":          {Type: OptionTypeBool},
+	"network.remote-dio":                   {Type: OptionTypeBool},
+	"features.read-only":                   {Type: OptionTypeBool},
+	"features.shard":                       {Type: OptionTypeBool},
+	"cluster.lookup-optimize":              {Type: OptionTypeBool},
+	"nfs.disable":                          {Type: OptionTypeBool},
+	"network.ping-timeout":                 {Type: OptionTypeInt, Min: 0, Max: 1013},
+	"performance.io-thread-count":          {Type: OptionTypeInt, Min: 1, Max: 64},
+	"client.event-threads":                 {Type: OptionTypeInt, Min: 1, Max: 32},
+	"server.event-threads":                 {Type: OptionTypeInt, Min: 1, Max: 1024},
+	"cluster.shd-max-threads":              {Type: OptionTypeInt, Min: 1, Max: 64},
+	"performance.cache-size":               {Type: OptionTypeSize},
+	"performance.cache-max-file-size":      {Type: OptionTypeSize},
+	"performance.cache-min-file-size":      {Type: OptionTypeSize},
+	"performance.write-behind-window-size": {Type: OptionTypeSize},
+	"features.shard-block-size":            {Type: OptionTypeSize},
+}
+
+// VolumeSetHelp is a func to get the catalog of Volume options which
+// can be set using volume set. help-xml prints the XML document itself,
+// so the command is run without --xml
+func VolumeSetHelp() ([]OptionCatalogEntry, error) {
+	var q OptionCatalog
+	cmd := []string{"volume", "set", "help-xml"}
+	data, err := ExecuteCmdOutput(cmd)
+	if err != nil {
+		return []OptionCatalogEntry{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return []OptionCatalogEntry{}, xmlerr
+	}
+
+	for idx, o := range q.List {
+		info, ok := optionTypes[o.Name]
+		if !ok {
+			continue
+		}
+		q.List[idx].Type = info.Type
+		if info.Min != 0 || info.Max != 0 {
+			min, max := info.Min, info.Max
+			q.List[idx].Min = &min
+			q.List[idx].Max = &max
+		}
+	}
+	return q.List, nil
+}
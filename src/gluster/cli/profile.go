@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// Allowed values of profile info type and top metrics
+var (
+	ProfileInfoTypes = []string{"peek", "incremental", "cumulative"}
+	TopMetrics       = []string{"open", "read", "write", "opendir", "readdir", "read-perf", "write-perf"}
+)
+
+// ProfileBlockStat object, number of reads and writes of a block size
+type ProfileBlockStat struct {
+	Size   int64 `xml:"size" json:"size"`
+	Reads  int64 `xml:"reads" json:"reads"`
+	Writes int64 `xml:"writes" json:"writes"`
+}
+
+// ProfileFopStat object, number of calls and latency(in microseconds)
+// of a FOP
+type ProfileFopStat struct {
+	Name       string  `xml:"name" json:"name"`
+	Hits       int64   `xml:"hits" json:"calls"`
+	AvgLatency float64 `xml:"avgLatency" json:"avg_latency"`
+	MinLatency float64 `xml:"minLatency" json:"min_latency"`
+	MaxLatency float64 `xml:"maxLatency" json:"max_latency"`
+}
+
+// ProfileStats object, cumulative or interval stats of a Brick
+type ProfileStats struct {
+	Interval   int                `xml:"interval" json:"interval,omitempty"`
+	Duration   int64              `xml:"duration" json:"duration"`
+	TotalRead  int64              `xml:"totalRead" json:"total_read"`
+	TotalWrite int64              `xml:"totalWrite" json:"total_write"`
+	BlockStats []ProfileBlockStat `xml:"blockStats>block" json:"block_stats"`
+	FopStats   []ProfileFopStat   `xml:"fopStats>fop" json:"fop_stats"`
+}
+
+// ProfileBrick object, profile stats of a Brick
+type ProfileBrick struct {
+	Name       string        `xml:"brickName" json:"name"`
+	Cumulative *ProfileStats `xml:"cumulativeStats" json:"cumulative,omitempty"`
+	Interval   *ProfileStats `xml:"intervalStats" json:"interval,omitempty"`
+}
+
+// ProfileInfo from Gluster profile info output
+type ProfileInfo struct {
+	XMLName xml.Name       `xml:"cliOutput"`
+	List    []ProfileBrick `xml:"volProfile>brick"`
+}
+
+// TopFile object, file entry of top output
+type TopFile struct {
+	Filename   string  `xml:"filename" json:"filename"`
+	Count      int64   `xml:"count" json:"count,omitempty"`
+	Throughput float64 `xml:"throughput" json:"throughput,omitempty"`
+	Time       string  `xml:"time" json:"time,omitempty"`
+}
+
+// TopBrick object, top output of a Brick
+type TopBrick struct {
+	Name       string    `xml:"name" json:"name"`
+	Members    int       `xml:"members" json:"members"`
+	Throughput float64   `xml:"throughput" json:"throughput,omitempty"`
+	TimeTaken  float64   `xml:"timeTaken" json:"time_taken,omitempty"`
+	Files      []TopFile `xml:"file" json:"files"`
+}
+
+// TopStats from Gluster top output
+type TopStats struct {
+	XMLName xml.Name   `xml:"cliOutput"`
+	List    []TopBrick `xml:"volTop>brick"`
+}
+
+// TopOptions - Options for top query. BlockSize and Count are used only
+// by read-perf and write-perf
+type TopOptions struct {
+	Brick     string
+	ListCount int
+	BlockSize int
+	Count     int
+}
+
+// VolumeProfileStart is a func to start profiling a Gluster Volume
+func VolumeProfileStart(volname string) error {
+	cmd := []string{"volume", "profile", volname, "start"}
+	return ExecuteCmd(cmd)
+}
+
+// VolumeProfileStop is a func to stop profiling a Gluster Volume
+func VolumeProfileStop(volname string) error {
+	cmd := []string{"volume", "profile", volname, "stop"}
+	return ExecuteCmd(cmd)
+}
+
+// VolumeProfileInfo is a func to get the profile stats of each Brick of
+// a Gluster Volume. Both cumulative and interval stats are returned if
+// infoType is empty, else one of peek, incremental or cumulative
+func VolumeProfileInfo(volname string, infoType string) ([]ProfileBrick, error) {
+	var q ProfileInfo
+	cmd := []string{"volume", "profile", volname, "info"}
+	if infoType != "" {
+		cmd = append(cmd, infoType)
+	}
+	data, err := ExecuteCmdXML(cmd)
+	if err != nil {
+		return []ProfileBrick{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return []ProfileBrick{}, xmlerr
+	}
+	return q.List, nil
+}
+
+// VolumeTop is a func to get the top stats of a Gluster Volume
+func VolumeTop(volname string, metric string, options TopOptions) ([]TopBrick, error) {
+	// volume top <VOLNAME> {open|read|write|opendir|readdir|clear}
+	// [nfs|brick <brick>] [list-cnt <value>] |
+	// volume top <VOLNAME> {read-perf|write-perf} [bs <size> count <count>]
+	// [brick <brick>] [list-cnt <value>]
+	var q TopStats
+	cmd := []string{"volume", "top", volname, metric}
+	if options.BlockSize != 0 && options.Count != 0 {
+		cmd = append(cmd, "bs", fmt.Sprintf("%d", options.BlockSize),
+			"count", fmt.Sprintf("%d", options.Count))
+	}
+	if options.Brick != "" {
+		cmd = append(cmd, "brick", options.Brick)
+	}
+	if options.ListCount != 0 {
+		cmd = append(cmd, "list-cnt", fmt.Sprintf("%d", options.ListCount))
+	}
+	data, err := ExecuteCmdXML(cmd)
+	if err != nil {
+		return []TopBrick{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return []TopBrick{}, xmlerr
+	}
+	return q.List, nil
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// QuotaLimit object, usage limit of a directory
+type QuotaLimit struct {
+	Path                 string `xml:"path" json:"path"`
+	HardLimit            string `xml:"hard_limit" json:"hard_limit"`
+	SoftLimitPercent     string `xml:"soft_limit_percent" json:"soft_limit_percent"`
+	SoftLimitValue       string `xml:"soft_limit_value" json:"soft_limit_value"`
+	UsedSpace            string `xml:"used_space" json:"used_space"`
+	AvailSpace           string `xml:"avail_space" json:"avail_space"`
+	SoftLimitExceededRaw string `xml:"sl_exceeded" json:"-"`
+	HardLimitExceededRaw string `xml:"hl_exceeded" json:"-"`
+	SoftLimitExceeded    bool   `json:"soft_limit_exceeded"`
+	HardLimitExceeded    bool   `json:"hard_limit_exceeded"`
+}
+
+// QuotaObjectLimit object, object(files and directories) count limit
+// of a directory
+type QuotaObjectLimit struct {
+	Path                 string `xml:"path" json:"path"`
+	HardLimit            string `xml:"hard_limit" json:"hard_limit"`
+	SoftLimitPercent     string `xml:"soft_limit_percent" json:"soft_limit_percent"`
+	SoftLimitValue       string `xml:"soft_limit_value" json:"soft_limit_value"`
+	FileCount            string `xml:"file_count" json:"file_count"`
+	DirCount             string `xml:"dir_count" json:"dir_count"`
+	Available            string `xml:"available" json:"available"`
+	SoftLimitExceededRaw string `xml:"sl_exceeded" json:"-"`
+	HardLimitExceededRaw string `xml:"hl_exceeded" json:"-"`
+	SoftLimitExceeded    bool   `json:"soft_limit_exceeded"`
+	HardLimitExceeded    bool   `json:"hard_limit_exceeded"`
+}
+
+// QuotaLimits from Gluster quota list output
+type QuotaLimits struct {
+	XMLName xml.Name     `xml:"cliOutput"`
+	List    []QuotaLimit `xml:"volQuota>limit"`
+}
+
+// QuotaObjectLimits from Gluster quota list-objects output
+type QuotaObjectLimits struct {
+	XMLName xml.Name           `xml:"cliOutput"`
+	List    []QuotaObjectLimit `xml:"volQuota>limit"`
+}
+
+// QuotaLimitOptions - Options to set Quota limits of a directory. Size
+// is usage limit(For example, 10GB) and Objects is the limit of number
+// of files and directories. SoftLimit is percentage of hard limit.
+type QuotaLimitOptions struct {
+	Path      string `json:"path"`
+	Size      string `json:"size"`
+	Objects   int    `json:"objects"`
+	SoftLimit int    `json:"soft_limit"`
+}
+
+// VolumeQuotaEnable is a func to enable Quota of a Gluster Volume
+func VolumeQuotaEnable(volname string) error {
+	cmd := []string{"volume", "quota", volname, "enable"}
+	return ExecuteCmd(cmd)
+}
+
+// VolumeQuotaDisable is a func to disable Quota of a Gluster Volume
+func VolumeQuotaDisable(volname string) error {
+	cmd := []string{"volume", "quota", volname, "disable"}
+	return ExecuteCmd(cmd)
+}
+
+// VolumeQuotaLimitUsage is a func to set usage limit of a directory
+func VolumeQuotaLimitUsage(volname string, path string, size string, softLimit int) error {
+	// volume quota <VOLNAME> limit-usage <path> <size> [<percent>]
+	cmd := []string{"volume", "quota", volname, "limit-usage", path, size}
+	if softLimit != 0 {
+		cmd = append(cmd, fmt.Sprintf("%d%%", softLimit))
+	}
+	return ExecuteCmd(cmd)
+}
+
+// VolumeQuotaLimitObjects is a func to set object count limit of a
+// directory
+func VolumeQuotaLimitObjects(volname string, path string, objects int, softLimit int) error {
+	// volume quota <VOLNAME> limit-objects <path> <number> [<percent>]
+	cmd := []string{"volume", "quota", volname, "limit-objects", path, fmt.Sprintf("%d", objects)}
+	if softLimit != 0 {
+		cmd = append(cmd, fmt.Sprintf("%d%%", softLimit))
+	}
+	return ExecuteCmd(cmd)
+}
+
+// VolumeQuotaRemove is a func to remove usage limit of a directory
+func VolumeQuotaRemove(volname string, path string) error {
+	cmd := []string{"volume", "quota", volname, "remove", path}
+	return ExecuteCmd(cmd)
+}
+
+// VolumeQuotaRemoveObjects is a func to remove object count limit of a
+// directory
+func VolumeQuotaRemoveObjects(volname string, path string) error {
+	cmd := []string{"volume", "quota", volname, "remove-objects", path}
+	return ExecuteCmd(cmd)
+}
+
+// VolumeQuotaList is a func to get usage limits of all the directories,
+// or only of the given paths
+func VolumeQuotaList(volname string, paths []string) ([]QuotaLimit, error) {
+	var q QuotaLimits
+	cmd := append([]string{"volume", "quota", volname, "list"}, paths...)
+	data, err := ExecuteCmdXML(cmd)
+	if err != nil {
+		return []QuotaLimit{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return []QuotaLimit{}, xmlerr
+	}
+	for idx, l := range q.List {
+		q.List[idx].SoftLimitExceeded = l.SoftLimitExceededRaw == "Yes"
+		q.List[idx].HardLimitExceeded = l.HardLimitExceededRaw == "Yes"
+	}
+	return q.List, nil
+}
+
+// VolumeQuotaListObjects is a func to get object count limits of all the
+// directories, or only of the given paths
+func VolumeQuotaListObjects(volname string, paths []string) ([]QuotaObjectLimit, error) {
+	var q QuotaObjectLimits
+	cmd := append([]string{"volume", "quota", volname, "list-objects"}, paths...)
+	data, err := ExecuteCmdXML(cmd)
+	if err != nil {
+		return []QuotaObjectLimit{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return []QuotaObjectLimit{}, xmlerr
+	}
+	for idx, l := range q.List {
+		q.List[idx].SoftLimitExceeded = l.SoftLimitExceededRaw == "Yes"
+		q.List[idx].HardLimitExceeded = l.HardLimitExceededRaw == "Yes"
+	}
+	return q.List, nil
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"encoding/xml"
+)
+
+// Rebalance status codes of a node, as reported by glusterd
+const (
+	RebalanceNotStarted = 0
+	RebalanceInProgress = 1
+	RebalanceStopped    = 2
+	RebalanceCompleted  = 3
+	RebalanceFailed     = 4
+)
+
+// RebalanceNode object, rebalance progress of a node
+type RebalanceNode struct {
+	Name      string  `xml:"nodeName" json:"name"`
+	ID        string  `xml:"id" json:"id,omitempty"`
+	Files     int64   `xml:"files" json:"files"`
+	Size      int64   `xml:"size" json:"size"`
+	Lookups   int64   `xml:"lookups" json:"scanned"`
+	Failures  int64   `xml:"failures" json:"failures"`
+	Skipped   int64   `xml:"skipped" json:"skipped"`
+	StatusRaw int     `xml:"status" json:"-"`
+	Status    string  `xml:"statusStr" json:"status"`
+	RunTime   float64 `xml:"runtime" json:"run_time"`
+}
+
+// RebalanceStatus from Gluster rebalance status output
+type RebalanceStatus struct {
+	XMLName   xml.Name        `xml:"cliOutput" json:"-"`
+	TaskID    string          `xml:"volRebalance>task-id" json:"task_id"`
+	Nodes     []RebalanceNode `xml:"volRebalance>node" json:"nodes"`
+	Aggregate RebalanceNode   `xml:"volRebalance>aggregate" json:"aggregate"`
+}
+
+// VolumeRebalanceStart is a func to start rebalance of a Gluster Volume.
+// If fixLayout is set, only the layout is fixed and no data is migrated.
+func VolumeRebalanceStart(volname string, fixLayout bool, force bool) error {
+	// volume rebalance <VOLNAME> {{fix-layout start} | {start [force]}}
+	cmd := []string{"volume", "rebalance", volname}
+	if fixLayout {
+		cmd = append(cmd, "fix-layout")
+	}
+	cmd = append(cmd, "start")
+	if force {
+		cmd = append(cmd, "force")
+	}
+	return ExecuteCmd(cmd)
+}
+
+// VolumeRebalanceStop is a func to stop rebalance of a Gluster Volume
+func VolumeRebalanceStop(volname string) error {
+	cmd := []string{"volume", "rebalance", volname, "stop"}
+	return ExecuteCmd(cmd)
+}
+
+// VolumeRebalanceStatus is a func to get the rebalance progress of
+// all the nodes of a Gluster Volume
+func VolumeRebalanceStatus(volname string) (RebalanceStatus, error) {
+	var q RebalanceStatus
+	cmd := []string{"volume", "rebalance", volname, "status"}
+	data, err := ExecuteCmdXML(cmd)
+	if err != nil {
+		return RebalanceStatus{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return RebalanceStatus{}, xmlerr
+	}
+	return q, nil
+}
@@ -0,0 +1,280 @@
+package cli
+
+import (
+	"encoding/xml"
+)
+
+// SnapOriginVolume object, Volume from which Snapshot is taken
+type SnapOriginVolume struct {
+	Name          string `xml:"name" json:"name"`
+	SnapCount     int    `xml:"snapCount" json:"snap_count"`
+	SnapRemaining int    `xml:"snapRemaining" json:"snap_remaining"`
+}
+
+// SnapVolume object, Snapshot Volume
+type SnapVolume struct {
+	Name         string           `xml:"name" json:"name"`
+	Status       string           `xml:"status" json:"status"`
+	OriginVolume SnapOriginVolume `xml:"originVolume" json:"origin_volume"`
+}
+
+// Snapshot object
+type Snapshot struct {
+	Name        string       `xml:"name" json:"name"`
+	ID          string       `xml:"uuid" json:"id"`
+	Description string       `xml:"description" json:"description,omitempty"`
+	CreateTime  string       `xml:"createTime" json:"create_time"`
+	VolCount    int          `xml:"volCount" json:"vol_count"`
+	Volumes     []SnapVolume `xml:"snapVolume" json:"volumes"`
+}
+
+// Snapshots - List of Snapshot objects
+type Snapshots struct {
+	XMLName xml.Name   `xml:"cliOutput"`
+	List    []Snapshot `xml:"snapInfo>snapshots>snapshot"`
+}
+
+// SnapListSnapshots - List of Snapshots from snapshot list command
+type SnapListSnapshots struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	List    []string `xml:"snapList>snapshot"`
+}
+
+// SnapBrick object, Brick of a Snapshot Volume
+type SnapBrick struct {
+	Path           string `xml:"path" json:"path"`
+	VolumeGroup    string `xml:"volumeGroup" json:"volume_group,omitempty"`
+	StatusRaw      string `xml:"status" json:"-"`
+	Online         bool   `json:"online"`
+	Pid            string `xml:"pid" json:"pid,omitempty"`
+	DataPercentage string `xml:"dataPercentage" json:"data_percentage,omitempty"`
+	LvSize         string `xml:"lvSize" json:"lv_size,omitempty"`
+}
+
+// SnapStatusVolume object, status of Snapshot Volume Bricks
+type SnapStatusVolume struct {
+	BrickCount int         `xml:"brickCount" json:"brick_count"`
+	Bricks     []SnapBrick `xml:"brick" json:"bricks"`
+}
+
+// SnapStatus object, status of a Snapshot
+type SnapStatus struct {
+	Name     string             `xml:"name" json:"name"`
+	ID       string             `xml:"uuid" json:"id"`
+	VolCount int                `xml:"volCount" json:"vol_count"`
+	Volumes  []SnapStatusVolume `xml:"volume" json:"volumes"`
+}
+
+// SnapsStatus from Gluster snapshot status output
+type SnapsStatus struct {
+	XMLName xml.Name     `xml:"cliOutput"`
+	List    []SnapStatus `xml:"snapStatus>snapshots>snapshot"`
+}
+
+// SnapCreateOptions - Options to create Snapshot
+type SnapCreateOptions struct {
+	Volume      string `json:"volume"`
+	Description string `json:"description"`
+	NoTimestamp bool   `json:"no_timestamp"`
+	Force       bool   `json:"force"`
+}
+
+// SnapCloneOptions - Options to clone a Volume from Snapshot
+type SnapCloneOptions struct {
+	Name  string `json:"name"`
+	Start bool   `json:"start"`
+}
+
+// SnapSystemConfig object, cluster wide Snapshot configuration
+type SnapSystemConfig struct {
+	HardLimit        string `xml:"hardLimit" json:"snap-max-hard-limit"`
+	SoftLimit        string `xml:"softLimit" json:"snap-max-soft-limit"`
+	AutoDelete       string `xml:"autoDelete" json:"auto-delete"`
+	ActivateOnCreate string `xml:"activateOnCreate" json:"activate-on-create"`
+}
+
+// SnapVolumeConfig object, Snapshot configuration of a Volume
+type SnapVolumeConfig struct {
+	Name               string `xml:"name" json:"name"`
+	HardLimit          string `xml:"hardLimit" json:"snap-max-hard-limit"`
+	EffectiveHardLimit string `xml:"effectiveHardLimit" json:"effective-hard-limit"`
+	SoftLimit          string `xml:"softLimit" json:"snap-max-soft-limit"`
+}
+
+// SnapConfig from Gluster snapshot config output
+type SnapConfig struct {
+	XMLName xml.Name           `xml:"cliOutput" json:"-"`
+	System  SnapSystemConfig   `xml:"snapConfig>systemConfig" json:"system"`
+	Volumes []SnapVolumeConfig `xml:"snapConfig>volumeConfig>volume" json:"volumes"`
+}
+
+type snapCreateOutput struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	Name    string   `xml:"snapCreate>snapshot>name"`
+}
+
+// SnapshotCreate is a func to create Snapshot of a Gluster Volume. Returns
+// the name of created Snapshot, timestamp is appended to the name
+// unless NoTimestamp is set
+func SnapshotCreate(snapname string, volname string, options SnapCreateOptions) (string, error) {
+	// snapshot create <snapname> <volname> [no-timestamp]
+	// [description <description>] [force]
+	var q snapCreateOutput
+	cmd := []string{"snapshot", "create", snapname, volname}
+	if options.NoTimestamp {
+		cmd = append(cmd, "no-timestamp")
+	}
+	if options.Description != "" {
+		cmd = append(cmd, "description", options.Description)
+	}
+	if options.Force {
+		cmd = append(cmd, "force")
+	}
+	data, err := ExecuteCmdXML(cmd)
+	if err != nil {
+		return "", err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return "", xmlerr
+	}
+	return q.Name, nil
+}
+
+// SnapshotList is a func to list the Snapshot names, all Snapshots are
+// listed if volname is empty
+func SnapshotList(volname string) ([]string, error) {
+	var q SnapListSnapshots
+	cmd := []string{"snapshot", "list"}
+	if volname != "" {
+		cmd = append(cmd, volname)
+	}
+	data, err := ExecuteCmdXML(cmd)
+	if err != nil {
+		return []string{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return []string{}, xmlerr
+	}
+	return q.List, nil
+}
+
+func snapshotTarget(snapname string, volname string) []string {
+	if snapname != "" {
+		return []string{snapname}
+	}
+	if volname != "" {
+		return []string{"volume", volname}
+	}
+	return []string{}
+}
+
+// SnapshotInfo is a func to get Snapshot information. Information of a
+// single Snapshot is returned if snapname is set, else all Snapshots of
+// volname or all Snapshots if both are empty
+func SnapshotInfo(snapname string, volname string) ([]Snapshot, error) {
+	var q Snapshots
+	cmd := append([]string{"snapshot", "info"}, snapshotTarget(snapname, volname)...)
+	data, err := ExecuteCmdXML(cmd)
+	if err != nil {
+		return []Snapshot{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return []Snapshot{}, xmlerr
+	}
+	return q.List, nil
+}
+
+// SnapshotStatus is a func to get Snapshot Brick status, snapname and
+// volname are handled same as SnapshotInfo
+func SnapshotStatus(snapname string, volname string) ([]SnapStatus, error) {
+	var q SnapsStatus
+	cmd := append([]string{"snapshot", "status"}, snapshotTarget(snapname, volname)...)
+	data, err := ExecuteCmdXML(cmd)
+	if err != nil {
+		return []SnapStatus{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return []SnapStatus{}, xmlerr
+	}
+	for idx, s := range q.List {
+		for idx1, v := range s.Volumes {
+			for idx2, b := range v.Bricks {
+				q.List[idx].Volumes[idx1].Bricks[idx2].Online = b.StatusRaw == "Yes"
+			}
+		}
+	}
+	return q.List, nil
+}
+
+// SnapshotActivate is a func to activate a Snapshot
+func SnapshotActivate(snapname string, force bool) error {
+	cmd := []string{"snapshot", "activate", snapname}
+	if force {
+		cmd = append(cmd, "force")
+	}
+	return ExecuteCmd(cmd)
+}
+
+// SnapshotDeactivate is a func to deactivate a Snapshot
+func SnapshotDeactivate(snapname string) error {
+	cmd := []string{"snapshot", "deactivate", snapname}
+	return ExecuteCmd(cmd)
+}
+
+// SnapshotDelete is a func to delete a Snapshot
+func SnapshotDelete(snapname string) error {
+	cmd := []string{"snapshot", "delete", snapname}
+	return ExecuteCmd(cmd)
+}
+
+// SnapshotRestore is a func to restore a Gluster Volume to the state of
+// Snapshot. Volume should be stopped before restore.
+func SnapshotRestore(snapname string) error {
+	cmd := []string{"snapshot", "restore", snapname}
+	return ExecuteCmd(cmd)
+}
+
+// SnapshotClone is a func to create a new writable Volume from an
+// activated Snapshot
+func SnapshotClone(clonename string, snapname string) error {
+	cmd := []string{"snapshot", "clone", clonename, snapname}
+	return ExecuteCmd(cmd)
+}
+
+// SnapshotConfigGet is a func to get the Snapshot configuration, cluster
+// wide configuration and configuration of all Volumes is returned if
+// volname is empty
+func SnapshotConfigGet(volname string) (SnapConfig, error) {
+	var q SnapConfig
+	cmd := []string{"snapshot", "config"}
+	if volname != "" {
+		cmd = append(cmd, volname)
+	}
+	data, err := ExecuteCmdXML(cmd)
+	if err != nil {
+		return SnapConfig{}, err
+	}
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		return SnapConfig{}, xmlerr
+	}
+	return q, nil
+}
+
+// SnapshotConfigSet is a func to set Snapshot configuration of a Volume,
+// configuration is set cluster wide if volname is empty
+func SnapshotConfigSet(volname string, key string, value string) error {
+	// snapshot config [volname] ([snap-max-hard-limit <count>]
+	// [snap-max-soft-limit <percent>]) | ([auto-delete <enable|disable>])
+	// | ([activate-on-create <enable|disable>])
+	cmd := []string{"snapshot", "config"}
+	if volname != "" {
+		cmd = append(cmd, volname)
+	}
+	cmd = append(cmd, key, value)
+	return ExecuteCmd(cmd)
+}
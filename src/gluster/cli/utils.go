@@ -25,3 +25,14 @@ func ExecuteCmdXML(cmd []string) ([]byte, error) {
 	}
 	return o, nil
 }
+
+// ExecuteCmdOutput is helper function to execute Gluster Command and
+// return the output
+func ExecuteCmdOutput(cmd []string) ([]byte, error) {
+	cmd = append([]string{"--mode=script"}, cmd...)
+	o, err := exec.Command("gluster", cmd...).CombinedOutput()
+	if err != nil {
+		return []byte(""), errors.New(strings.Trim(string(o), "\n"))
+	}
+	return o, nil
+}
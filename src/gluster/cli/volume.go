@@ -2,8 +2,9 @@ package cli
 
 import (
 	"encoding/xml"
-
+	"errors"
 	"fmt"
+	"strings"
 )
 
 // VolumeOption object
@@ -12,6 +13,29 @@ type VolumeOption struct {
 	Value string `xml:"value" json:"value,omitempty"`
 }
 
+// VolumeOptionInfo object, effective value of a Volume option. ExplicitlySet
+// is false if the value is default
+type VolumeOptionInfo struct {
+	Name          string `json:"name"`
+	Value         string `json:"value"`
+	ExplicitlySet bool   `json:"explicitly_set"`
+}
+
+// volGetOpt is option from volume get output
+type volGetOpt struct {
+	Name  string `xml:"Option"`
+	Value string `xml:"Value"`
+}
+
+// volGetOpts from volume get output. Options are not enclosed in Opt
+// element if output has only one option
+type volGetOpts struct {
+	XMLName xml.Name    `xml:"cliOutput"`
+	List    []volGetOpt `xml:"volGetopts>Opt"`
+	Name    string      `xml:"volGetopts>Option"`
+	Value   string      `xml:"volGetopts>Value"`
+}
+
 // Transport type
 type Transport string
 
@@ -64,6 +88,196 @@ type BricksStatus struct {
 	List    []Brick  `xml:"volStatus>volumes>volume>node"`
 }
 
+// StatusViews - Volume status views other than detail
+var StatusViews = []string{"clients", "mem", "inode", "fd", "callpool", "tasks"}
+
+// BrickNode object, common fields of a Brick in Volume status output
+type BrickNode struct {
+	Hostname  string `xml:"hostname" json:"hostname"`
+	Path      string `xml:"path" json:"path"`
+	HostUUID  string `xml:"peerid" json:"host_id"`
+	StatusRaw int    `xml:"status" json:"-"`
+}
+
+// BrickClient object, client connected to a Brick
+type BrickClient struct {
+	Hostname   string `xml:"hostname" json:"hostname"`
+	BytesRead  int64  `xml:"bytesRead" json:"bytes_read"`
+	BytesWrite int64  `xml:"bytesWrite" json:"bytes_written"`
+	OpVersion  int    `xml:"opVersion" json:"op_version"`
+}
+
+// BrickClients object, clients connected to a Brick
+type BrickClients struct {
+	BrickNode
+	ClientCount int           `xml:"clientsStatus>clientCount" json:"client_count"`
+	Clients     []BrickClient `xml:"clientsStatus>client" json:"clients"`
+}
+
+// BricksClientsStatus from Gluster status clients output
+type BricksClientsStatus struct {
+	XMLName xml.Name       `xml:"cliOutput"`
+	List    []BrickClients `xml:"volStatus>volumes>volume>node"`
+}
+
+// Mallinfo object, memory allocation info of a Brick process
+type Mallinfo struct {
+	Arena    int64 `xml:"arena" json:"arena"`
+	Ordblks  int64 `xml:"ordblks" json:"ordblks"`
+	Smblks   int64 `xml:"smblks" json:"smblks"`
+	Hblks    int64 `xml:"hblks" json:"hblks"`
+	Hblkhd   int64 `xml:"hblkhd" json:"hblkhd"`
+	Usmblks  int64 `xml:"usmblks" json:"usmblks"`
+	Fsmblks  int64 `xml:"fsmblks" json:"fsmblks"`
+	Uordblks int64 `xml:"uordblks" json:"uordblks"`
+	Fordblks int64 `xml:"fordblks" json:"fordblks"`
+	Keepcost int64 `xml:"keepcost" json:"keepcost"`
+}
+
+// Mempool object, memory pool stats of a Brick process
+type Mempool struct {
+	Name         string `xml:"name" json:"name"`
+	HotCount     int64  `xml:"hotCount" json:"hot_count"`
+	ColdCount    int64  `xml:"coldCount" json:"cold_count"`
+	PaddedSizeOf int64  `xml:"padddedSizeOf" json:"padded_size_of"`
+	AllocCount   int64  `xml:"allocCount" json:"alloc_count"`
+	MaxAlloc     int64  `xml:"maxAlloc" json:"max_alloc"`
+	PoolMisses   int64  `xml:"poolMisses" json:"pool_misses"`
+	MaxStdAlloc  int64  `xml:"maxStdAlloc" json:"max_std_alloc"`
+}
+
+// BrickMem object, memory stats of a Brick process
+type BrickMem struct {
+	BrickNode
+	Mallinfo Mallinfo  `xml:"memStatus>mallinfo" json:"mallinfo"`
+	Mempools []Mempool `xml:"memStatus>mempool>pool" json:"mempools"`
+}
+
+// BricksMemStatus from Gluster status mem output
+type BricksMemStatus struct {
+	XMLName xml.Name   `xml:"cliOutput"`
+	List    []BrickMem `xml:"volStatus>volumes>volume>node"`
+}
+
+// Inode object, inode in Brick inode table
+type Inode struct {
+	GFID    string `xml:"gfid" json:"gfid"`
+	NLookup int64  `xml:"nLookup" json:"nlookup"`
+	Ref     int64  `xml:"ref" json:"ref"`
+	Type    int    `xml:"ia_type" json:"type"`
+}
+
+// InodeList object, list of inodes in a state
+type InodeList struct {
+	Count  int     `xml:"count" json:"count"`
+	Inodes []Inode `xml:"inode" json:"inodes"`
+}
+
+// InodeTable object, inode table of a Brick
+type InodeTable struct {
+	Active InodeList `xml:"active" json:"active"`
+	Lru    InodeList `xml:"lru" json:"lru"`
+	Purge  InodeList `xml:"purge" json:"purge"`
+}
+
+// BrickInodes object, inode tables of a Brick
+type BrickInodes struct {
+	BrickNode
+	Tables []InodeTable `xml:"inodeStatus>conn>itable" json:"tables"`
+}
+
+// BricksInodeStatus from Gluster status inode output
+type BricksInodeStatus struct {
+	XMLName xml.Name      `xml:"cliOutput"`
+	List    []BrickInodes `xml:"volStatus>volumes>volume>node"`
+}
+
+// Fd object, open fd of a Brick
+type Fd struct {
+	Entry    int    `xml:"entry" json:"entry"`
+	GFID     string `xml:"gfid" json:"gfid"`
+	RefCount int    `xml:"refCount" json:"ref_count"`
+	Flags    int    `xml:"flags" json:"flags"`
+}
+
+// FdTable object, fd table of a Brick
+type FdTable struct {
+	RefCount  int  `xml:"refCount" json:"ref_count"`
+	MaxFds    int  `xml:"maxFds" json:"max_fds"`
+	FirstFree int  `xml:"firstFree" json:"first_free"`
+	Fds       []Fd `xml:"fd" json:"fds"`
+}
+
+// BrickFds object, fd tables of a Brick
+type BrickFds struct {
+	BrickNode
+	Tables []FdTable `xml:"fdStatus>conn>fdTable" json:"tables"`
+}
+
+// BricksFdStatus from Gluster status fd output
+type BricksFdStatus struct {
+	XMLName xml.Name   `xml:"cliOutput"`
+	List    []BrickFds `xml:"volStatus>volumes>volume>node"`
+}
+
+// CallFrame object, frame of a pending call
+type CallFrame struct {
+	RefCount   int    `xml:"refCount" json:"ref_count"`
+	Translator string `xml:"translator" json:"translator"`
+	Complete   int    `xml:"complete" json:"complete"`
+	Parent     string `xml:"parent" json:"parent,omitempty"`
+	WindFrom   string `xml:"windFrom" json:"wind_from,omitempty"`
+	WindTo     string `xml:"windTo" json:"wind_to,omitempty"`
+	UnwindFrom string `xml:"unwindFrom" json:"unwind_from,omitempty"`
+	UnwindTo   string `xml:"unwindTo" json:"unwind_to,omitempty"`
+}
+
+// CallStack object, pending call in a Brick
+type CallStack struct {
+	UID    int         `xml:"uid" json:"uid"`
+	GID    int         `xml:"gid" json:"gid"`
+	PID    int         `xml:"pid" json:"pid"`
+	Unique string      `xml:"unique" json:"unique"`
+	Op     string      `xml:"op" json:"op"`
+	Type   int         `xml:"type" json:"type"`
+	Count  int         `xml:"count" json:"count"`
+	Frames []CallFrame `xml:"callFrame" json:"frames"`
+}
+
+// BrickCallpool object, pending calls of a Brick
+type BrickCallpool struct {
+	BrickNode
+	Count      int         `xml:"callpoolStatus>count" json:"count"`
+	CallStacks []CallStack `xml:"callpoolStatus>callStack" json:"call_stacks"`
+}
+
+// BricksCallpoolStatus from Gluster status callpool output
+type BricksCallpoolStatus struct {
+	XMLName xml.Name        `xml:"cliOutput"`
+	List    []BrickCallpool `xml:"volStatus>volumes>volume>node"`
+}
+
+// VolumeTask object, task(rebalance or remove-brick) running on a Volume
+type VolumeTask struct {
+	Type      string   `xml:"type" json:"type"`
+	ID        string   `xml:"id" json:"id"`
+	StatusRaw int      `xml:"status" json:"-"`
+	Status    string   `xml:"statusStr" json:"status"`
+	Bricks    []string `xml:"params>brick" json:"bricks,omitempty"`
+}
+
+// VolumeTasks object, tasks of a Volume
+type VolumeTasks struct {
+	Name  string       `xml:"volName" json:"name"`
+	Tasks []VolumeTask `xml:"tasks>task" json:"tasks"`
+}
+
+// VolumesTasksStatus from Gluster status tasks output
+type VolumesTasksStatus struct {
+	XMLName xml.Name      `xml:"cliOutput"`
+	List    []VolumeTasks `xml:"volStatus>volumes>volume"`
+}
+
 // Volumes - List of Volume objects
 type Volumes struct {
 	XMLName xml.Name `xml:"cliOutput"`
@@ -162,6 +376,13 @@ func VolumeOptSet(volname string, key string, value string) error {
 	return ExecuteCmd(cmd)
 }
 
+// VolumeOptSetGroup is a func to set the group of options(For example,
+// virt or db-workload) defined in glusterd groups directory
+func VolumeOptSetGroup(volname string, group string) error {
+	cmd := []string{"volume", "set", volname, "group", group}
+	return ExecuteCmd(cmd)
+}
+
 // VolumeOptReset is a func to reset option of a Gluster Volume
 func VolumeOptReset(volname string, key string, force bool) error {
 	cmd := []string{"volume", "reset", volname}
@@ -189,15 +410,99 @@ func VolumeOptGet(volname string, key string) ([]VolumeOption, error) {
 			return []VolumeOption{}, xmlerr
 		}
 		return q.List[0].Options, nil
-	} else if key == "all" {
-		// If key is "all" run volume get <VOL> all and return output
-		// TODO: Open issue with xml output
-		return []VolumeOption{}, nil
-	} else {
-		// If key is set then get for that key
-		// TODO: Open issue with xml output
-		return []VolumeOption{}, nil
 	}
+
+	// If key is "all" run volume get <VOL> all, else get for that key.
+	// Older versions of Gluster ignore --xml for volume get, parse the
+	// text output in that case
+	cmd := []string{"volume", "get", volname, key}
+	data, err := ExecuteCmdXML(cmd)
+	if err != nil {
+		return []VolumeOption{}, err
+	}
+
+	var q volGetOpts
+	xmlerr := xml.Unmarshal(data, &q)
+	if xmlerr != nil {
+		opts := parseVolGetText(string(data))
+		if len(opts) == 0 {
+			return []VolumeOption{}, xmlerr
+		}
+		return opts, nil
+	}
+
+	opts := []VolumeOption{}
+	for _, o := range q.List {
+		opts = append(opts, VolumeOption{Name: o.Name, Value: o.Value})
+	}
+	if q.Name != "" {
+		opts = append(opts, VolumeOption{Name: q.Name, Value: q.Value})
+	}
+	return opts, nil
+}
+
+// parseVolGetText parses the text output of volume get command
+//
+//	Option                                  Value
+//	------                                  -----
+//	cluster.lookup-unhashed                 on
+func parseVolGetText(data string) []VolumeOption {
+	opts := []VolumeOption{}
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] == "Option" || fields[0] == "------" {
+			continue
+		}
+		value := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), fields[0]))
+		opts = append(opts, VolumeOption{Name: fields[0], Value: value})
+	}
+	return opts
+}
+
+// ErrOptionNotFound is returned if the Volume option does not exist
+var ErrOptionNotFound = errors.New("Option does not exist")
+
+// volumeOptionExists checks if the key is in the list of options, key
+// can be the full name or the name without translator prefix
+func volumeOptionExists(opts []VolumeOption, key string) bool {
+	for _, o := range opts {
+		if o.Name == key || strings.HasSuffix(o.Name, "."+key) {
+			return true
+		}
+	}
+	return false
+}
+
+// VolumeOptInfo is a func to get the effective value of a Gluster Volume
+// option and whether it is explicitly set on the Volume
+func VolumeOptInfo(volname string, key string) (VolumeOptionInfo, error) {
+	opts, err := VolumeOptGet(volname, key)
+	if err != nil {
+		// volume get fails for unknown key, confirm using the list of
+		// all the options to differentiate from other failures
+		all, errAll := VolumeOptGet(volname, "all")
+		if errAll == nil && !volumeOptionExists(all, key) {
+			return VolumeOptionInfo{}, ErrOptionNotFound
+		}
+		return VolumeOptionInfo{}, err
+	}
+	if len(opts) == 0 {
+		return VolumeOptionInfo{}, ErrOptionNotFound
+	}
+
+	setOpts, err := VolumeOptGet(volname, "")
+	if err != nil {
+		return VolumeOptionInfo{}, err
+	}
+
+	info := VolumeOptionInfo{Name: opts[0].Name, Value: opts[0].Value}
+	for _, o := range setOpts {
+		if o.Name == info.Name {
+			info.ExplicitlySet = true
+			break
+		}
+	}
+	return info, nil
 }
 
 // VolumeLogRotate is a utility func to initiate log rotate on a Gluster Volume
@@ -334,3 +639,47 @@ func VolumeBarrierDisable(volname string) error {
 	cmd := []string{"volume", "barrier", volname, "disable"}
 	return ExecuteCmd(cmd)
 }
+
+// VolumeStatusView is a utility func to get Volume status of a view
+// other than detail(clients, mem, inode, fd, callpool or tasks). Returned
+// value is list of view specific objects, for example []BrickClients
+// for clients view
+func VolumeStatusView(volname string, view string) (interface{}, error) {
+	vol := "all"
+	if volname != "" {
+		vol = volname
+	}
+	cmd := []string{"volume", "status", vol, view}
+	data, err := ExecuteCmdXML(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	switch view {
+	case "clients":
+		var q BricksClientsStatus
+		err = xml.Unmarshal(data, &q)
+		return q.List, err
+	case "mem":
+		var q BricksMemStatus
+		err = xml.Unmarshal(data, &q)
+		return q.List, err
+	case "inode":
+		var q BricksInodeStatus
+		err = xml.Unmarshal(data, &q)
+		return q.List, err
+	case "fd":
+		var q BricksFdStatus
+		err = xml.Unmarshal(data, &q)
+		return q.List, err
+	case "callpool":
+		var q BricksCallpoolStatus
+		err = xml.Unmarshal(data, &q)
+		return q.List, err
+	case "tasks":
+		var q VolumesTasksStatus
+		err = xml.Unmarshal(data, &q)
+		return q.List, err
+	}
+	return nil, fmt.Errorf("Invalid Volume status view: %s", view)
+}
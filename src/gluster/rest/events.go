@@ -0,0 +1,253 @@
+package main
+
+import (
+	"strings"
+	"sync"
+
+	"gluster/utils"
+)
+
+const (
+	// Size of the channel buffer of each subscriber, events are dropped
+	// for the subscriber if it is slow to consume
+	subscriberBufferSize = 64
+
+	// Number of recent Events kept in memory for the clients to resume
+	recentEventsSize = 1024
+
+	// Maximum number of older Events read from Events store when a
+	// client resumes
+	eventsBackfillMax = 10000
+)
+
+// Event - Gluster Event as sent by glustereventsd, Seq is assigned
+// when the Event is published
+type Event struct {
+	Seq     uint64            `json:"seq"`
+	NodeID  string            `json:"nodeid"`
+	TS      int64             `json:"ts"`
+	Event   string            `json:"event"`
+	Message map[string]string `json:"message"`
+}
+
+// Volume returns the name of the Volume to which the Event belongs,
+// empty if the Event is not related to any Volume
+func (e Event) Volume() string {
+	for _, key := range []string{"volume", "volume_name", "master_volume"} {
+		if v, ok := e.Message[key]; ok {
+			return v
+		}
+	}
+	if strings.HasPrefix(e.Event, "VOLUME_") || strings.HasPrefix(e.Event, "BITROT_") {
+		return e.Message["name"]
+	}
+	return ""
+}
+
+// eventFilter - Server side filter for the subscribers, empty
+// list means no filter. If App is limited to some Volumes then only
+// the Events of those Volumes are matched
+type eventFilter struct {
+	Types   []string
+	Volumes []string
+	App     utils.App
+}
+
+func newEventFilter(types string, volumes string) eventFilter {
+	var f eventFilter
+	for _, t := range strings.Split(types, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			f.Types = append(f.Types, strings.ToUpper(t))
+		}
+	}
+	for _, v := range strings.Split(volumes, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			f.Volumes = append(f.Volumes, v)
+		}
+	}
+	return f
+}
+
+func (f eventFilter) Match(e Event) bool {
+	if len(f.Types) > 0 && !utils.StringInList(e.Event, f.Types) {
+		return false
+	}
+	if len(f.Volumes) > 0 && !utils.StringInList(e.Volume(), f.Volumes) {
+		return false
+	}
+	return f.App.VolumeAllowed(e.Volume())
+}
+
+// eventsRing is a bounded ring buffer of recent Events
+type eventsRing struct {
+	events []Event
+	next   int
+	full   bool
+}
+
+func newEventsRing(size int) *eventsRing {
+	return &eventsRing{events: make([]Event, size)}
+}
+
+// Add adds the Event, overwriting the oldest if the buffer is full
+func (r *eventsRing) Add(e Event) {
+	r.events[r.next] = e
+	r.next = (r.next + 1) % len(r.events)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Since returns the Events with sequence number greater than seq, in
+// the order they were added
+func (r *eventsRing) Since(seq uint64) []Event {
+	var ordered []Event
+	if r.full {
+		ordered = append(ordered, r.events[r.next:]...)
+	}
+	ordered = append(ordered, r.events[:r.next]...)
+
+	out := []Event{}
+	for _, e := range ordered {
+		if e.Seq > seq {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// eventsHub fans out the Events to all the in-process subscribers
+type eventsHub struct {
+	sync.Mutex
+	seq         uint64
+	recent      *eventsRing
+	subscribers map[chan Event]struct{}
+
+	// publishLock serializes the publishers, Events are saved in
+	// Events store holding only this lock so that the subscribers
+	// are not blocked by the disk writes
+	publishLock sync.Mutex
+}
+
+var hub = eventsHub{
+	recent:      newEventsRing(recentEventsSize),
+	subscribers: make(map[chan Event]struct{}),
+}
+
+// SetSeq sets the last sequence number, used to continue the sequence
+// numbers from the Events store after restart
+func (h *eventsHub) SetSeq(seq uint64) {
+	h.Lock()
+	defer h.Unlock()
+	h.seq = seq
+}
+
+// Subscribe returns a channel which receives all the published Events
+func (h *eventsHub) Subscribe() chan Event {
+	h.Lock()
+	defer h.Unlock()
+	ch := make(chan Event, subscriberBufferSize)
+	h.subscribers[ch] = struct{}{}
+	return ch
+}
+
+// Since returns the published Events after the given sequence number,
+// in the order they were published. Events which are not in memory are
+// read from Events store without holding the lock, since the Events
+// are saved before they are published
+func (h *eventsHub) Since(seq uint64) ([]Event, error) {
+	h.Lock()
+	last := h.seq
+	recent := h.recent.Since(seq)
+	h.Unlock()
+
+	if seq >= last || (len(recent) > 0 && recent[0].Seq == seq+1) {
+		return recent, nil
+	}
+
+	to := last + 1
+	if len(recent) > 0 {
+		to = recent[0].Seq
+	}
+	stored, err := eventsStoreRange(seq, to, eventsBackfillMax)
+	if err != nil {
+		return recent, err
+	}
+	return append(stored, recent...), nil
+}
+
+// SubscribeSince returns a channel which receives all the published
+// Events and the recent Events after the given sequence number. Both
+// are collected under the same lock so that no Event is missed. If
+// the recent Events in memory do not cover the seq(For example, after
+// restart) then the older Events are read from the Events store. If
+// the seq is newer than the last published Event then all the recent
+// Events are returned
+func (h *eventsHub) SubscribeSince(seq uint64) (chan Event, []Event) {
+	h.Lock()
+	defer h.Unlock()
+	ch := make(chan Event, subscriberBufferSize)
+	h.subscribers[ch] = struct{}{}
+	if seq > h.seq {
+		seq = 0
+	}
+
+	recent := h.recent.Since(seq)
+	if seq == 0 || seq == h.seq || (len(recent) > 0 && recent[0].Seq == seq+1) {
+		return ch, recent
+	}
+
+	to := h.seq + 1
+	if len(recent) > 0 {
+		to = recent[0].Seq
+	}
+	stored, err := eventsStoreRange(seq, to, eventsBackfillMax)
+	if err != nil {
+		if err != errEventsStoreNotAvailable {
+			utils.Logger.Error("Unable to read Events from Events store: ", err)
+		}
+		return ch, recent
+	}
+	return ch, append(stored, recent...)
+}
+
+// Unsubscribe removes the subscriber and closes its channel
+func (h *eventsHub) Unsubscribe(ch chan Event) {
+	h.Lock()
+	defer h.Unlock()
+	if _, ok := h.subscribers[ch]; ok {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Publish assigns the next sequence number to the Event, saves it in
+// Events store and then sends it to all the subscribers without
+// blocking. Event is not published if saving fails, so that the
+// published Events can always be read again from Events store
+func (h *eventsHub) Publish(e Event) (Event, error) {
+	h.publishLock.Lock()
+	defer h.publishLock.Unlock()
+
+	h.Lock()
+	e.Seq = h.seq + 1
+	h.Unlock()
+
+	err := eventsStoreAdd(e)
+	if err != nil && err != errEventsStoreNotAvailable {
+		return e, err
+	}
+
+	h.Lock()
+	defer h.Unlock()
+	h.seq = e.Seq
+	h.recent.Add(e)
+	for ch := range h.subscribers {
+		select {
+		case ch <- e:
+		default:
+			// Slow subscriber, drop the Event
+		}
+	}
+	return e, nil
+}
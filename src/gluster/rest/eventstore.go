@@ -0,0 +1,284 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"gluster/utils"
+)
+
+// Retention is applied with this period
+const eventsRetentionPeriod = time.Hour
+
+var eventsBucket = []byte("events")
+
+var errEventsStoreNotAvailable = errors.New("Events history is not available")
+
+// eventsDB is nil if the Events store failed to open
+var eventsDB *bolt.DB
+
+// eventsQuery - Filters and pagination for Events history. Cursor is
+// the sequence number of last Event of previous page
+type eventsQuery struct {
+	Since  int64
+	Until  int64
+	Filter eventFilter
+	Nodes  []string
+	Cursor uint64
+	Limit  int
+}
+
+// eventsPage - One page of Events history, NextCursor is empty if no
+// more Events
+type eventsPage struct {
+	Events     []Event `json:"events"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+func seqKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return key
+}
+
+// eventsStoreOpen opens the Events store and returns the sequence
+// number of the last stored Event
+func eventsStoreOpen(path string) (uint64, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return 0, err
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return 0, err
+	}
+
+	var lastSeq uint64
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{webhookDeliveriesBucket, webhookDeadLettersBucket} {
+			_, err := tx.CreateBucketIfNotExists(bucket)
+			if err != nil {
+				return err
+			}
+		}
+		b, err := tx.CreateBucketIfNotExists(eventsBucket)
+		if err != nil {
+			return err
+		}
+		if k, _ := b.Cursor().Last(); k != nil {
+			lastSeq = binary.BigEndian.Uint64(k)
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return 0, err
+	}
+
+	eventsDB = db
+	return lastSeq, nil
+}
+
+// eventsStoreAdd saves the Event in Events store
+func eventsStoreAdd(e Event) error {
+	if eventsDB == nil {
+		return errEventsStoreNotAvailable
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	return eventsDB.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(eventsBucket).Put(seqKey(e.Seq), data)
+	})
+}
+
+func (q eventsQuery) Match(e Event) bool {
+	if q.Since > 0 && e.TS < q.Since {
+		return false
+	}
+	if q.Until > 0 && e.TS > q.Until {
+		return false
+	}
+	if len(q.Nodes) > 0 && !utils.StringInList(e.NodeID, q.Nodes) {
+		return false
+	}
+	return q.Filter.Match(e)
+}
+
+// eventsStoreRange returns the newest Events with sequence number
+// greater than from and less than to, at most limit Events are
+// returned in the order they were received
+func eventsStoreRange(from uint64, to uint64, limit int) ([]Event, error) {
+	events := []Event{}
+	if eventsDB == nil {
+		return events, errEventsStoreNotAvailable
+	}
+
+	err := eventsDB.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(eventsBucket).Cursor()
+		k, v := c.Seek(seqKey(to))
+		if k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+		for ; k != nil && len(events) < limit; k, v = c.Prev() {
+			if binary.BigEndian.Uint64(k) <= from {
+				break
+			}
+			var e Event
+			err := json.Unmarshal(v, &e)
+			if err != nil {
+				return err
+			}
+			events = append(events, e)
+		}
+		return nil
+	})
+	if err != nil {
+		return []Event{}, err
+	}
+
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	return events, nil
+}
+
+// eventsStoreQuery returns the Events matching the query in the order
+// they were received
+func eventsStoreQuery(q eventsQuery) (eventsPage, error) {
+	page := eventsPage{Events: []Event{}}
+	if eventsDB == nil {
+		return page, errEventsStoreNotAvailable
+	}
+
+	err := eventsDB.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(eventsBucket).Cursor()
+		for k, v := c.Seek(seqKey(q.Cursor + 1)); k != nil; k, v = c.Next() {
+			var e Event
+			err := json.Unmarshal(v, &e)
+			if err != nil {
+				return err
+			}
+			if !q.Match(e) {
+				continue
+			}
+			if len(page.Events) == q.Limit {
+				page.NextCursor = strconv.FormatUint(page.Events[len(page.Events)-1].Seq, 10)
+				return nil
+			}
+			page.Events = append(page.Events, e)
+		}
+		return nil
+	})
+	return page, err
+}
+
+// eventsStoreRetention deletes the Events older than retention days
+// and the oldest Events if number of Events exceeds the retention count
+// or if size of the Events exceeds the retention bytes. Size is the
+// total size of the stored Events, the store file does not shrink
+// after delete and the free space is reused for new Events. Zero value
+// disables the respective retention
+func eventsStoreRetention(days int, count int, bytes int64) error {
+	if eventsDB == nil {
+		return errEventsStoreNotAvailable
+	}
+
+	// Events to delete are found using read only transaction so that
+	// the Events are not blocked from saving during the scan. Events
+	// are only added after the last Event, so deleting till the found
+	// sequence number is safe
+	var lastSeq uint64
+	minTS := time.Now().AddDate(0, 0, -days).Unix()
+	err := eventsDB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(eventsBucket)
+		excess := 0
+		if count > 0 {
+			excess = b.Stats().KeyN - count
+		}
+		var excessBytes int64
+		if bytes > 0 {
+			c := b.Cursor()
+			for k, v := c.First(); k != nil; k, v = c.Next() {
+				excessBytes += int64(len(v))
+			}
+			excessBytes -= bytes
+		}
+
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			if excess <= 0 && excessBytes <= 0 {
+				if days <= 0 {
+					break
+				}
+				var e Event
+				err := json.Unmarshal(v, &e)
+				if err == nil && e.TS >= minTS {
+					break
+				}
+			}
+			lastSeq = binary.BigEndian.Uint64(k)
+			excess--
+			excessBytes -= int64(len(v))
+		}
+		return nil
+	})
+	if err != nil || lastSeq == 0 {
+		return err
+	}
+
+	return eventsDB.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(eventsBucket)
+
+		// Collect the keys first, deleting using the cursor while
+		// iterating skips the entries
+		var keys [][]byte
+		c := b.Cursor()
+		for k, _ := c.First(); k != nil && binary.BigEndian.Uint64(k) <= lastSeq; k, _ = c.Next() {
+			keys = append(keys, append([]byte{}, k...))
+		}
+
+		for _, k := range keys {
+			err := b.Delete(k)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// EventsStoreStart opens the Events store, continues the sequence
+// numbers from the last stored Event and applies the retention
+// periodically. Events history is disabled if the store fails to open
+func EventsStoreStart() {
+	lastSeq, err := eventsStoreOpen(utils.RestConfig.EventsDBFile)
+	if err != nil {
+		utils.Logger.Error("Unable to open Events store ", utils.RestConfig.EventsDBFile, ": ", err)
+		return
+	}
+	hub.SetSeq(lastSeq)
+
+	go func() {
+		for {
+			err := eventsStoreRetention(utils.RestConfig.EventsRetentionDays,
+				utils.RestConfig.EventsRetentionCount, utils.RestConfig.EventsRetentionBytes)
+			if err != nil {
+				utils.Logger.Error("Unable to apply Events retention: ", err)
+			}
+			time.Sleep(eventsRetentionPeriod)
+		}
+	}()
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"regexp"
+)
+
+// Event names are upper case identifiers like VOLUME_CREATE
+var eventNameRe = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// eventSchema - Known Gluster Events and the keys required in the
+// message of each Event. Events not listed here are accepted if the
+// name is valid, since newer versions of Gluster add new Events
+var eventSchema = map[string][]string{
+	// Volume
+	"VOLUME_CREATE":             {"name"},
+	"VOLUME_START":              {"name"},
+	"VOLUME_STOP":               {"name"},
+	"VOLUME_DELETE":             {"name"},
+	"VOLUME_SET":                {"name", "options"},
+	"VOLUME_RESET":              {"name", "option"},
+	"VOLUME_REBALANCE_START":    {"volume"},
+	"VOLUME_REBALANCE_STOP":     {"volume"},
+	"VOLUME_REBALANCE_FAILED":   {"volume"},
+	"VOLUME_REBALANCE_COMPLETE": {"volume"},
+
+	// Peer
+	"PEER_ATTACH":     {"host"},
+	"PEER_DETACH":     {"host"},
+	"PEER_CONNECT":    {"host", "uuid"},
+	"PEER_DISCONNECT": {"uuid"},
+	"PEER_REJECT":     {"peer"},
+
+	// Brick
+	"BRICK_CONNECTED":    {"peer", "volume", "brick"},
+	"BRICK_DISCONNECTED": {"peer", "volume", "brick"},
+	"BRICK_RESET_START":  {"volume"},
+	"BRICK_RESET_COMMIT": {"volume"},
+	"BRICK_REPLACE":      {"volume"},
+
+	// Quota
+	"QUOTA_ENABLE":             {"volume"},
+	"QUOTA_DISABLE":            {"volume"},
+	"QUOTA_SET_USAGE_LIMIT":    {"volume", "path", "limit"},
+	"QUOTA_SET_OBJECTS_LIMIT":  {"volume", "path", "limit"},
+	"QUOTA_REMOVE_USAGE_LIMIT": {"volume", "path"},
+	"QUOTA_CROSSED_SOFT_LIMIT": {"volume", "path"},
+
+	// Snapshot
+	"SNAPSHOT_CREATED":       {"snapshot_name", "volume_name"},
+	"SNAPSHOT_CREATE_FAILED": {"volume_name"},
+	"SNAPSHOT_ACTIVATED":     {"snapshot_name"},
+	"SNAPSHOT_DEACTIVATED":   {"snapshot_name"},
+	"SNAPSHOT_DELETED":       {"snapshot_name"},
+	"SNAPSHOT_RESTORED":      {"snapshot_name", "volume_name"},
+
+	// Geo-replication
+	"GEOREP_CREATE": {"master", "slave"},
+	"GEOREP_START":  {"master", "slave"},
+	"GEOREP_STOP":   {"master", "slave"},
+	"GEOREP_PAUSE":  {"master", "slave"},
+	"GEOREP_RESUME": {"master", "slave"},
+	"GEOREP_DELETE": {"master", "slave"},
+	"GEOREP_FAULTY": {"master_volume", "slave_host", "slave_volume"},
+
+	// Bitrot
+	"BITROT_ENABLE":   {"name"},
+	"BITROT_DISABLE":  {"name"},
+	"BITROT_BAD_FILE": {"gfid", "brick"},
+
+	// Self heal
+	"AFR_QUORUM_FAIL": {"subvol"},
+
+	// Services
+	"SVC_CONNECTED":    {"volume", "svc_name"},
+	"SVC_DISCONNECTED": {"volume", "svc_name"},
+}
+
+// Validate checks the Event against the schema and returns the errors
+// as field name and error message
+func (e Event) Validate() map[string]string {
+	errs := make(map[string]string)
+	if e.NodeID == "" {
+		errs["nodeid"] = "required"
+	}
+	if e.TS <= 0 {
+		errs["ts"] = "should be a positive timestamp"
+	}
+	if !eventNameRe.MatchString(e.Event) {
+		errs["event"] = "invalid Event name"
+		return errs
+	}
+	for _, key := range eventSchema[e.Event] {
+		if _, ok := e.Message[key]; !ok {
+			errs["message."+key] = "required for " + e.Event
+		}
+	}
+	return errs
+}
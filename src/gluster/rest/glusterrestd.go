@@ -13,6 +13,9 @@ func main() {
 	utils.Autoload(defaultConfigPath, customConfigPath)
 	router := mux.NewRouter().StrictSlash(true)
 	AddRoutes(router)
+	SnapSchedulerStart()
+	EventsStoreStart()
+	WebhooksStart()
 
 	portData := fmt.Sprintf(":%d", utils.RestConfig.Port)
 	utils.Logger.Info("Started running REST server in port ", utils.RestConfig.Port)
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"gluster/cli"
+	"gluster/utils"
+)
+
+// VolumeBitrotEnable is a HTTP handler to enable bitrot detection of a
+// Gluster Volume
+func VolumeBitrotEnable(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	err := cli.VolumeBitrotEnable(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// VolumeBitrotDisable is a HTTP handler to disable bitrot detection of a
+// Gluster Volume
+func VolumeBitrotDisable(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	err := cli.VolumeBitrotDisable(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// VolumeScrubSet is a HTTP handler to set scrub throttle and/or scrub
+// frequency of a Gluster Volume
+func VolumeScrubSet(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var opts cli.ScrubOptions
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if opts.Throttle == "" && opts.Frequency == "" {
+		utils.HTTPErrorJSON(w, "throttle or frequency is required", http.StatusBadRequest)
+		return
+	}
+	if opts.Throttle != "" && !utils.StringInList(opts.Throttle, cli.ScrubThrottles) {
+		utils.HTTPErrorJSON(w, "Invalid scrub throttle: "+opts.Throttle, http.StatusBadRequest)
+		return
+	}
+	if opts.Frequency != "" && !utils.StringInList(opts.Frequency, cli.ScrubFrequencies) {
+		utils.HTTPErrorJSON(w, "Invalid scrub frequency: "+opts.Frequency, http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	if opts.Throttle != "" {
+		err := cli.VolumeScrubThrottle(volName, opts.Throttle)
+		if err != nil {
+			utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if opts.Frequency != "" {
+		err := cli.VolumeScrubFrequency(volName, opts.Frequency)
+		if err != nil {
+			utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// VolumeScrubPause is a HTTP handler to pause the scrubber of a Gluster
+// Volume
+func VolumeScrubPause(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	err := cli.VolumeScrubPause(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// VolumeScrubResume is a HTTP handler to resume the scrubber of a Gluster
+// Volume
+func VolumeScrubResume(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	err := cli.VolumeScrubResume(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// VolumeScrubStatus is a HTTP handler to get the scrubber status of a
+// Gluster Volume
+func VolumeScrubStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	info, err := cli.VolumeScrubStatus(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"gluster/cli"
+	"gluster/utils"
+)
+
+// subvolSize returns number of bricks in each subvolume of the Volume
+func subvolSize(vol cli.Volume) int {
+	if vol.DisperseCount > 0 {
+		return vol.DisperseCount
+	}
+	if vol.ReplicaCount > 0 {
+		return vol.ReplicaCount
+	}
+	return 1
+}
+
+// volumeHasBrick checks if the given brick(<HOST>:<PATH>) is part of the Volume
+func volumeHasBrick(vol cli.Volume, brick string) bool {
+	for _, b := range vol.Bricks {
+		if b.Name == brick {
+			return true
+		}
+	}
+	return false
+}
+
+// validateAddBrick validates the number of bricks against the existing
+// Volume layout before running the add-brick command
+func validateAddBrick(vol cli.Volume, opts cli.AddBrickOptions) error {
+	numBricks := len(opts.Bricks)
+	if numBricks == 0 {
+		return errors.New("No bricks specified")
+	}
+
+	if opts.ArbiterCount != 0 && opts.ReplicaCount == 0 {
+		return errors.New("arbiter requires replica count")
+	}
+
+	if opts.DisperseCount != 0 && opts.DisperseCount != vol.DisperseCount {
+		return fmt.Errorf("Disperse count can't be changed, Volume disperse count is %d", vol.DisperseCount)
+	}
+
+	size := subvolSize(vol)
+	if opts.ReplicaCount != 0 && opts.ReplicaCount != vol.ReplicaCount {
+		// Replica count change, one new brick per subvolume for each
+		// increase in replica count
+		if vol.DisperseCount > 0 {
+			return errors.New("Replica count can't be changed for disperse Volume")
+		}
+		if opts.ReplicaCount < vol.ReplicaCount {
+			return fmt.Errorf("Replica count can't be reduced from %d to %d using add-brick", vol.ReplicaCount, opts.ReplicaCount)
+		}
+		expected := (vol.NumBricks / size) * (opts.ReplicaCount - vol.ReplicaCount)
+		if numBricks != expected {
+			return fmt.Errorf("Number of bricks should be %d to change replica count from %d to %d", expected, vol.ReplicaCount, opts.ReplicaCount)
+		}
+		return nil
+	}
+
+	if numBricks%size != 0 {
+		return fmt.Errorf("Number of bricks should be a multiple of %d", size)
+	}
+	return nil
+}
+
+// VolumeAddBrick is a HTTP handler to add Bricks to a Gluster Volume
+func VolumeAddBrick(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var opts cli.AddBrickOptions
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	info, err := cli.VolumeInfo(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(info) == 0 {
+		utils.HTTPErrorJSON(w, "Volume does not exist", http.StatusNotFound)
+		return
+	}
+
+	errValidate := validateAddBrick(info[0], opts)
+	if errValidate != nil {
+		utils.HTTPErrorJSON(w, errValidate.Error(), http.StatusBadRequest)
+		return
+	}
+
+	errAdd := cli.VolumeAddBrick(volName, opts.Bricks, opts)
+	if errAdd != nil {
+		utils.HTTPErrorJSON(w, errAdd.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// VolumeRemoveBrickStart is a HTTP handler to start removing Bricks from
+// a Gluster Volume. Data from the bricks will be migrated to remaining
+// bricks, use commit once migration is complete.
+func VolumeRemoveBrickStart(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var opts cli.RemoveBrickOptions
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(opts.Bricks) == 0 {
+		utils.HTTPErrorJSON(w, "No bricks specified", http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	info, err := cli.VolumeInfo(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(info) == 0 {
+		utils.HTTPErrorJSON(w, "Volume does not exist", http.StatusNotFound)
+		return
+	}
+
+	for _, b := range opts.Bricks {
+		if !volumeHasBrick(info[0], b) {
+			utils.HTTPErrorJSON(w, fmt.Sprintf("Brick %s is not part of Volume", b), http.StatusBadRequest)
+			return
+		}
+	}
+
+	errRemove := cli.VolumeRemoveBrickStart(volName, opts.Bricks, opts)
+	if errRemove != nil {
+		utils.HTTPErrorJSON(w, errRemove.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// VolumeRemoveBrickStatus is a HTTP handler to get data migration status
+// of the bricks being removed. Bricks are specified using query
+// parameter, for example ?brick=host1:/b1&brick=host2:/b2
+func VolumeRemoveBrickStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	bricks := r.URL.Query()["brick"]
+	if len(bricks) == 0 {
+		utils.HTTPErrorJSON(w, "No bricks specified", http.StatusBadRequest)
+		return
+	}
+
+	info, err := cli.VolumeRemoveBrickStatus(volName, bricks)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
+
+// VolumeRemoveBrickCommit is a HTTP handler to commit the remove-brick
+// operation. Commit is refused unless data migration is completed on
+// all the nodes.
+func VolumeRemoveBrickCommit(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var opts cli.RemoveBrickOptions
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(opts.Bricks) == 0 {
+		utils.HTTPErrorJSON(w, "No bricks specified", http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	status, err := cli.VolumeRemoveBrickStatus(volName, opts.Bricks)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Commit only after data migration is completed on all the nodes,
+	// else data in the removed bricks is lost
+	if len(status.Nodes) == 0 {
+		utils.HTTPErrorJSON(w, "Data migration status is not available", http.StatusConflict)
+		return
+	}
+	for _, n := range status.Nodes {
+		if n.StatusRaw != cli.RebalanceCompleted {
+			utils.HTTPErrorJSON(w, fmt.Sprintf("Data migration is not completed on %s, status: %s", n.Name, n.Status), http.StatusConflict)
+			return
+		}
+	}
+
+	errCommit := cli.VolumeRemoveBrickCommit(volName, opts.Bricks, opts)
+	if errCommit != nil {
+		utils.HTTPErrorJSON(w, errCommit.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// VolumeRemoveBrickStop is a HTTP handler to stop the remove-brick
+// operation, bricks will remain part of the Volume
+func VolumeRemoveBrickStop(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var opts cli.RemoveBrickOptions
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(opts.Bricks) == 0 {
+		utils.HTTPErrorJSON(w, "No bricks specified", http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	errStop := cli.VolumeRemoveBrickStop(volName, opts.Bricks)
+	if errStop != nil {
+		utils.HTTPErrorJSON(w, errStop.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// volumeGetWithBrick gets the Volume information and validates that the
+// brick is part of the Volume. Error response is written if validation
+// fails, returns false in that case.
+func volumeGetWithBrick(w http.ResponseWriter, volName string, brick string) (cli.Volume, bool) {
+	info, err := cli.VolumeInfo(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return cli.Volume{}, false
+	}
+	if len(info) == 0 {
+		utils.HTTPErrorJSON(w, "Volume does not exist", http.StatusNotFound)
+		return cli.Volume{}, false
+	}
+	if !volumeHasBrick(info[0], brick) {
+		utils.HTTPErrorJSON(w, fmt.Sprintf("Brick %s is not part of Volume", brick), http.StatusNotFound)
+		return cli.Volume{}, false
+	}
+	return info[0], true
+}
+
+// volumeBricksOut writes the updated list of bricks of the Volume
+func volumeBricksOut(w http.ResponseWriter, volName string) {
+	info, err := cli.VolumeInfo(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(info) == 0 {
+		utils.HTTPErrorJSON(w, "Volume does not exist", http.StatusNotFound)
+		return
+	}
+	utils.HTTPOutJSON(w, info[0].Bricks)
+}
+
+// VolumeReplaceBrick is a HTTP handler to replace a Brick of a Gluster
+// Volume, responds with the updated list of Bricks
+func VolumeReplaceBrick(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var opts cli.ReplaceBrickOptions
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if opts.Destination == "" {
+		utils.HTTPErrorJSON(w, "Destination brick not specified", http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	brick := vars["brick"]
+	vol, ok := volumeGetWithBrick(w, volName, brick)
+	if !ok {
+		return
+	}
+	if volumeHasBrick(vol, opts.Destination) {
+		utils.HTTPErrorJSON(w, fmt.Sprintf("Brick %s is already part of Volume", opts.Destination), http.StatusBadRequest)
+		return
+	}
+
+	errReplace := cli.VolumeReplaceBrick(volName, brick, opts.Destination)
+	if errReplace != nil {
+		utils.HTTPErrorJSON(w, errReplace.Error(), http.StatusInternalServerError)
+		return
+	}
+	volumeBricksOut(w, volName)
+}
+
+// VolumeResetBrick is a HTTP handler to start or commit the reset of a
+// Brick of a Gluster Volume, responds with the updated list of Bricks
+func VolumeResetBrick(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var opts cli.ResetBrickOptions
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	brick := vars["brick"]
+	if _, ok := volumeGetWithBrick(w, volName, brick); !ok {
+		return
+	}
+
+	errReset := cli.VolumeResetBrick(volName, brick, opts)
+	if errReset != nil {
+		utils.HTTPErrorJSON(w, errReset.Error(), http.StatusInternalServerError)
+		return
+	}
+	volumeBricksOut(w, volName)
+}
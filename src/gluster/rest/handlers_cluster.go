@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"gluster/cli"
+	"gluster/utils"
+)
+
+const opVersionKey = "cluster.op-version"
+
+// opVersionSetRequest - Request to bump the Cluster op-version, if
+// OpVersion is not specified then max op-version is used
+type opVersionSetRequest struct {
+	OpVersion int `json:"op_version"`
+}
+
+// ClusterOptionsGet is a HTTP handler to get the Cluster wide options
+func ClusterOptionsGet(w http.ResponseWriter, r *http.Request) {
+	info, err := cli.ClusterOptGet("")
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
+
+// ClusterOptionsSet is a HTTP handler to set the Cluster wide options,
+// op-version is not allowed here since it is managed via op-version API
+func ClusterOptionsSet(w http.ResponseWriter, r *http.Request) {
+	var opts = make(map[string]string)
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := opts[opVersionKey]; ok {
+		utils.HTTPErrorJSON(w, "Use /v1/cluster/op-version to change the op-version", http.StatusBadRequest)
+		return
+	}
+
+	var keys []string
+	for k := range opts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		err := cli.ClusterOptSet(k, opts[k])
+		if err != nil {
+			utils.HTTPErrorJSON(w, k+": "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// ClusterOptionsReset is a HTTP handler to reset the Cluster wide options
+func ClusterOptionsReset(w http.ResponseWriter, r *http.Request) {
+	var opts []string
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, k := range opts {
+		if k == opVersionKey {
+			utils.HTTPErrorJSON(w, "op-version can not be reset", http.StatusBadRequest)
+			return
+		}
+		err := cli.ClusterOptReset(k)
+		if err != nil {
+			utils.HTTPErrorJSON(w, k+": "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// ClusterOpVersionGet is a HTTP handler to get the current and max
+// op-version of Cluster
+func ClusterOpVersionGet(w http.ResponseWriter, r *http.Request) {
+	info, err := cli.ClusterOpVersion()
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
+
+// ClusterOpVersionSet is a HTTP handler to bump the Cluster op-version.
+// Refused if any of the peers is disconnected, since the disconnected
+// peers will be rejected when they come back with older op-version
+func ClusterOpVersionSet(w http.ResponseWriter, r *http.Request) {
+	var req opVersionSetRequest
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&req)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	info, err := cli.ClusterOpVersion()
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if req.OpVersion == 0 {
+		req.OpVersion = info.Max
+	}
+
+	if req.OpVersion < info.Current {
+		utils.HTTPErrorJSON(w, fmt.Sprintf("op-version can not be lowered from %d to %d", info.Current, req.OpVersion), http.StatusBadRequest)
+		return
+	}
+
+	if req.OpVersion > info.Max {
+		utils.HTTPErrorJSON(w, fmt.Sprintf("op-version %d is greater than max supported op-version %d", req.OpVersion, info.Max), http.StatusBadRequest)
+		return
+	}
+
+	if req.OpVersion == info.Current {
+		utils.HTTPOutJSON(w, info)
+		return
+	}
+
+	peers, err := cli.PoolList()
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var disconnected []string
+	for _, p := range peers {
+		if p.Connected != 1 {
+			disconnected = append(disconnected, p.Hostname)
+		}
+	}
+	if len(disconnected) > 0 {
+		utils.HTTPErrorJSON(w, "Peers disconnected: "+strings.Join(disconnected, ", "), http.StatusConflict)
+		return
+	}
+
+	err = cli.ClusterOpVersionSet(req.OpVersion)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	info.Current = req.OpVersion
+	utils.HTTPOutJSON(w, info)
+}
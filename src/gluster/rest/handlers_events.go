@@ -0,0 +1,303 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"gluster/utils"
+)
+
+const (
+	// Time allowed to write a message to the client
+	wsWriteWait = 10 * time.Second
+
+	// Client should respond to ping within this time
+	wsPongWait = 60 * time.Second
+
+	// Send pings to client with this period, must be less than wsPongWait
+	wsPingPeriod = (wsPongWait * 9) / 10
+
+	// Heartbeat comment is sent with this period to keep the idle
+	// Server-Sent Events connections alive through the proxies
+	sseHeartbeatPeriod = 15 * time.Second
+
+	// Default and maximum number of Events in a page of Events history
+	eventsPageSize    = 100
+	eventsPageSizeMax = 1000
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Dashboards can be served from different origin, access is
+	// already verified using the JWT token
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// requestEventFilter returns the filter from query params type and
+// volume, limited to the Volumes in the scope of the App of request
+func requestEventFilter(r *http.Request) eventFilter {
+	filter := newEventFilter(r.URL.Query().Get("type"), r.URL.Query().Get("volume"))
+	if app, ok := requestApp(r); ok {
+		filter.App = app
+	}
+	return filter
+}
+
+// EventsWebsocket is a HTTP handler to stream the Gluster Events to the
+// clients over WebSocket. Events can be filtered using the query params
+// type=<EVENT>,<EVENT> and volume=<VOLNAME>,<VOLNAME>
+func EventsWebsocket(w http.ResponseWriter, r *http.Request) {
+	filter := requestEventFilter(r)
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade already sent the error response to the client
+		utils.Logger.Error("Websocket upgrade failed: ", err)
+		return
+	}
+	defer conn.Close()
+
+	events := hub.Subscribe()
+	defer hub.Unsubscribe(events)
+
+	// Messages from client are not expected, read is required to process
+	// the control messages(ping, pong and close)
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		conn.SetPongHandler(func(string) error {
+			conn.SetReadDeadline(time.Now().Add(wsPongWait))
+			return nil
+		})
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case e := <-events:
+			if !filter.Match(e) {
+				continue
+			}
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteJSON(e); err != nil {
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// EventsListen is a HTTP handler to receive the Gluster Events from
+// glustereventsd(registered as webhook). Events are validated, assigned
+// a sequence number and published to all the subscribers
+func EventsListen(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var e Event
+	err := decoder.Decode(&e)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fieldErrors := e.Validate()
+	if len(fieldErrors) > 0 {
+		utils.HTTPFieldErrorsJSON(w, "Invalid Event", fieldErrors, http.StatusBadRequest)
+		return
+	}
+
+	e, err = hub.Publish(e)
+	if err != nil {
+		utils.Logger.Error("Unable to save Event ", e.Seq, " in Events store: ", err)
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, e)
+}
+
+// EventsStream is a HTTP handler to stream the Gluster Events as
+// Server-Sent Events, alternative to WebSocket for the clients behind
+// proxies. Sequence number of the Event is sent as id, clients can
+// resume using Last-Event-ID header. If the Events after Last-Event-ID
+// are no longer available, reset event is sent before the available
+// Events. Query params type and volume filter the Events same as
+// EventsWebsocket
+func EventsStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		utils.HTTPErrorJSON(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	var lastID uint64
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		var err error
+		lastID, err = strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			utils.HTTPErrorJSON(w, "Invalid Last-Event-ID", http.StatusBadRequest)
+			return
+		}
+	}
+
+	filter := requestEventFilter(r)
+
+	// Without Last-Event-ID only the new Events are sent
+	events, recent := hub.SubscribeSince(lastID)
+	defer hub.Unsubscribe(events)
+	if lastID == 0 {
+		recent = nil
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// Events after Last-Event-ID are neither in memory nor in Events
+	// store, client is told to reset its state
+	if lastID > 0 && len(recent) > 0 && recent[0].Seq != lastID+1 {
+		if err := writeSSEReset(w, lastID, recent[0].Seq); err != nil {
+			return
+		}
+	}
+
+	for _, e := range recent {
+		if filter.Match(e) {
+			if err := writeSSEEvent(w, e); err != nil {
+				return
+			}
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(sseHeartbeatPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case e := <-events:
+			if !filter.Match(e) {
+				continue
+			}
+			if err := writeSSEEvent(w, e); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func writeSSEEvent(w http.ResponseWriter, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\ndata: %s\n\n", e.Seq, data)
+	return err
+}
+
+// sseReset - Sent as reset event when the Events after Last-Event-ID
+// are no longer available
+type sseReset struct {
+	LastEventID uint64 `json:"last_event_id"`
+	FirstSeq    uint64 `json:"first_seq"`
+}
+
+func writeSSEReset(w http.ResponseWriter, lastID uint64, firstSeq uint64) error {
+	data, err := json.Marshal(sseReset{LastEventID: lastID, FirstSeq: firstSeq})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: reset\ndata: %s\n\n", data)
+	return err
+}
+
+// isWebsocketRequest matches the WebSocket upgrade requests, Events
+// history and WebSocket are served using the same URL
+func isWebsocketRequest(r *http.Request, rm *mux.RouteMatch) bool {
+	return websocket.IsWebSocketUpgrade(r)
+}
+
+// EventsHistoryGet is a HTTP handler to query the stored Events. Query
+// params since and until(Unix timestamp), type, volume and node(comma
+// separated) filter the Events. Results are paginated, use next_cursor
+// from response as cursor to get the next page
+func EventsHistoryGet(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	q := eventsQuery{
+		Filter: requestEventFilter(r),
+		Limit:  eventsPageSize,
+	}
+
+	for _, n := range strings.Split(params.Get("node"), ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			q.Nodes = append(q.Nodes, n)
+		}
+	}
+
+	var err error
+	fieldErrors := make(map[string]string)
+	if v := params.Get("since"); v != "" {
+		if q.Since, err = strconv.ParseInt(v, 10, 64); err != nil {
+			fieldErrors["since"] = "should be a Unix timestamp"
+		}
+	}
+	if v := params.Get("until"); v != "" {
+		if q.Until, err = strconv.ParseInt(v, 10, 64); err != nil {
+			fieldErrors["until"] = "should be a Unix timestamp"
+		}
+	}
+	if v := params.Get("cursor"); v != "" {
+		if q.Cursor, err = strconv.ParseUint(v, 10, 64); err != nil {
+			fieldErrors["cursor"] = "invalid cursor"
+		}
+	}
+	if v := params.Get("limit"); v != "" {
+		if q.Limit, err = strconv.Atoi(v); err != nil || q.Limit < 1 || q.Limit > eventsPageSizeMax {
+			fieldErrors["limit"] = fmt.Sprintf("should be between 1 and %d", eventsPageSizeMax)
+		}
+	}
+	if len(fieldErrors) > 0 {
+		utils.HTTPFieldErrorsJSON(w, "Invalid query", fieldErrors, http.StatusBadRequest)
+		return
+	}
+
+	page, err := eventsStoreQuery(q)
+	if err == errEventsStoreNotAvailable {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, page)
+}
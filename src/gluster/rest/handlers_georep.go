@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/gorilla/mux"
+	"gluster/cli"
+	"gluster/utils"
+)
+
+// georepSession returns Master Volume, remote host and remote Volume
+// of the geo-replication session from URL
+func georepSession(r *http.Request) (string, string, string) {
+	vars := mux.Vars(r)
+	return vars["volName"], vars["remoteHost"], vars["remoteVol"]
+}
+
+// GeorepCreate is a HTTP handler to create geo-replication session
+func GeorepCreate(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var opts cli.GeorepCreateOptions
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	volName, remoteHost, remoteVol := georepSession(r)
+	errCreate := cli.GeorepCreate(volName, remoteHost, remoteVol, opts)
+	if errCreate != nil {
+		utils.HTTPErrorJSON(w, errCreate.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// georepAction runs the given geo-replication action(start, stop, pause
+// or resume). Use force=1 to force the action
+func georepAction(w http.ResponseWriter, r *http.Request, action func(string, string, string, bool) error) {
+	volName, remoteHost, remoteVol := georepSession(r)
+	force := r.URL.Query().Get("force") == "1"
+	err := action(volName, remoteHost, remoteVol, force)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GeorepStart is a HTTP handler to start geo-replication session
+func GeorepStart(w http.ResponseWriter, r *http.Request) {
+	georepAction(w, r, cli.GeorepStart)
+}
+
+// GeorepStop is a HTTP handler to stop geo-replication session
+func GeorepStop(w http.ResponseWriter, r *http.Request) {
+	georepAction(w, r, cli.GeorepStop)
+}
+
+// GeorepPause is a HTTP handler to pause geo-replication session
+func GeorepPause(w http.ResponseWriter, r *http.Request) {
+	georepAction(w, r, cli.GeorepPause)
+}
+
+// GeorepResume is a HTTP handler to resume geo-replication session
+func GeorepResume(w http.ResponseWriter, r *http.Request) {
+	georepAction(w, r, cli.GeorepResume)
+}
+
+// GeorepDelete is a HTTP handler to delete geo-replication session. Use
+// reset_sync_time=1 to sync all the data again if session is recreated
+func GeorepDelete(w http.ResponseWriter, r *http.Request) {
+	volName, remoteHost, remoteVol := georepSession(r)
+	resetSyncTime := r.URL.Query().Get("reset_sync_time") == "1"
+	err := cli.GeorepDelete(volName, remoteHost, remoteVol, resetSyncTime)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GeorepStatus is a HTTP handler to get the worker status of each Brick
+// of geo-replication session
+func GeorepStatus(w http.ResponseWriter, r *http.Request) {
+	volName, remoteHost, remoteVol := georepSession(r)
+	info, err := cli.GeorepStatusGet(volName, remoteHost, remoteVol)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
+
+// GeorepConfigGet is a HTTP handler to get geo-replication configurations
+func GeorepConfigGet(w http.ResponseWriter, r *http.Request) {
+	volName, remoteHost, remoteVol := georepSession(r)
+	info, err := cli.GeorepConfigGet(volName, remoteHost, remoteVol)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
+
+// GeorepConfigSet is a HTTP handler to set geo-replication configurations
+func GeorepConfigSet(w http.ResponseWriter, r *http.Request) {
+	var opts = make(map[string]string)
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var keys []string
+	for k := range opts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	volName, remoteHost, remoteVol := georepSession(r)
+	for _, k := range keys {
+		err := cli.GeorepConfigSet(volName, remoteHost, remoteVol, k, opts[k])
+		if err != nil {
+			utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// GeorepConfigReset is a HTTP handler to reset the given list of
+// geo-replication configurations to default
+func GeorepConfigReset(w http.ResponseWriter, r *http.Request) {
+	var opts []string
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	volName, remoteHost, remoteVol := georepSession(r)
+	for _, k := range opts {
+		err := cli.GeorepConfigReset(volName, remoteHost, remoteVol, k)
+		if err != nil {
+			utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
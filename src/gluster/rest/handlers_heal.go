@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"gluster/cli"
+	"gluster/utils"
+)
+
+// VolumeHealInfo is a HTTP handler to get entries pending heal in each
+// Brick of a Gluster Volume. Use split_brain=1 to list only the entries
+// in split-brain
+func VolumeHealInfo(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	var info []cli.HealBrick
+	var err error
+	if r.URL.Query().Get("split_brain") == "1" {
+		info, err = cli.VolumeHealInfoSplitBrain(volName)
+	} else {
+		info, err = cli.VolumeHealInfo(volName)
+	}
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
+
+// VolumeHealSummary is a HTTP handler to get the heal statistics of each
+// Brick of a Gluster Volume
+func VolumeHealSummary(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	info, err := cli.VolumeHealSummary(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
+
+// VolumeHeal is a HTTP handler to trigger heal of a Gluster Volume. Use
+// full=1 to trigger full heal instead of index heal
+func VolumeHeal(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	full := r.URL.Query().Get("full") == "1"
+	err := cli.VolumeHeal(volName, full)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// VolumeHealSplitBrain is a HTTP handler to resolve split-brain of a
+// Gluster Volume using bigger-file, latest-mtime or source-brick policy
+func VolumeHealSplitBrain(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var opts cli.SplitBrainOptions
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch opts.Policy {
+	case cli.SplitBrainBiggerFile, cli.SplitBrainLatestMtime:
+		if opts.File == "" {
+			utils.HTTPErrorJSON(w, "file is required for "+opts.Policy+" policy", http.StatusBadRequest)
+			return
+		}
+	case cli.SplitBrainSourceBrick:
+		if opts.SourceBrick == "" {
+			utils.HTTPErrorJSON(w, "source_brick is required for "+opts.Policy+" policy", http.StatusBadRequest)
+			return
+		}
+	default:
+		utils.HTTPErrorJSON(w, "Invalid split-brain policy", http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	errHeal := cli.VolumeHealSplitBrain(volName, opts)
+	if errHeal != nil {
+		utils.HTTPErrorJSON(w, errHeal.Error(), http.StatusInternalServerError)
+		return
+	}
+}
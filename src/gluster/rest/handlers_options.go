@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gluster/cli"
+	"gluster/utils"
+)
+
+// optionCatalog is loaded from Gluster on first use and cached, since
+// it changes only when Gluster is upgraded
+var optionCatalog = struct {
+	sync.Mutex
+	list    []cli.OptionCatalogEntry
+	options map[string]cli.OptionCatalogEntry
+}{}
+
+func loadOptionCatalog() ([]cli.OptionCatalogEntry, map[string]cli.OptionCatalogEntry, error) {
+	optionCatalog.Lock()
+	defer optionCatalog.Unlock()
+	if optionCatalog.options != nil {
+		return optionCatalog.list, optionCatalog.options, nil
+	}
+
+	list, err := cli.VolumeSetHelp()
+	if err != nil {
+		return nil, nil, err
+	}
+	options := make(map[string]cli.OptionCatalogEntry)
+	for _, o := range list {
+		options[o.Name] = o
+	}
+	optionCatalog.list = list
+	optionCatalog.options = options
+	return list, options, nil
+}
+
+// catalogLookup finds the option in catalog. Gluster accepts the option
+// name without the translator prefix if it is unique, for example
+// readdir-ahead for performance.readdir-ahead
+func catalogLookup(options map[string]cli.OptionCatalogEntry, key string) (cli.OptionCatalogEntry, bool) {
+	if opt, ok := options[key]; ok {
+		return opt, true
+	}
+
+	var found []cli.OptionCatalogEntry
+	for name, opt := range options {
+		if strings.HasSuffix(name, "."+key) {
+			found = append(found, opt)
+		}
+	}
+	if len(found) == 1 {
+		return found[0], true
+	}
+	return cli.OptionCatalogEntry{}, false
+}
+
+var optionSizeRegex = regexp.MustCompile(`(?i)^[0-9]+(\.[0-9]+)?\s*([KMGTP]B?|B)?$`)
+
+// validateOptionValue validates the value of a Volume option if the type
+// of the option is known, else it is left to glusterd
+func validateOptionValue(opt cli.OptionCatalogEntry, value string) error {
+	value = strings.TrimSpace(value)
+	switch opt.Type {
+	case cli.OptionTypeBool:
+		if !utils.StringInList(strings.ToLower(value), cli.BoolValues) {
+			return fmt.Errorf("should be one of %s", strings.Join(cli.BoolValues, ", "))
+		}
+	case cli.OptionTypeInt:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("should be an integer")
+		}
+		if opt.Min != nil && n < *opt.Min {
+			return fmt.Errorf("should be greater than or equal to %d", *opt.Min)
+		}
+		if opt.Max != nil && n > *opt.Max {
+			return fmt.Errorf("should be less than or equal to %d", *opt.Max)
+		}
+	case cli.OptionTypeSize:
+		if !optionSizeRegex.MatchString(value) {
+			return fmt.Errorf("should be a size, for example 128KB")
+		}
+	}
+	return nil
+}
+
+// validateVolumeOptions validates the options using option catalog and
+// returns the errors of each invalid option. User defined options
+// (user.*) are not part of catalog and are not validated. Values of
+// the options without type in catalog are validated by glusterd
+func validateVolumeOptions(opts map[string]string) (map[string]string, error) {
+	_, options, err := loadOptionCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	fieldErrors := make(map[string]string)
+	for k, v := range opts {
+		if strings.HasPrefix(k, "user.") {
+			continue
+		}
+		opt, ok := catalogLookup(options, k)
+		if !ok {
+			fieldErrors[k] = "Unknown option"
+			continue
+		}
+		errValue := validateOptionValue(opt, v)
+		if errValue != nil {
+			fieldErrors[k] = "Invalid value, " + errValue.Error()
+		}
+	}
+	return fieldErrors, nil
+}
+
+// volumeOptionsSetResult - Result of setting multiple Volume options.
+// If setting any option fails, options applied till then are rolled
+// back to previous values
+type volumeOptionsSetResult struct {
+	Ok             bool     `json:"ok"`
+	Message        string   `json:"message,omitempty"`
+	Applied        []string `json:"applied"`
+	RolledBack     []string `json:"rolled_back"`
+	RollbackFailed []string `json:"rollback_failed,omitempty"`
+}
+
+// setVolumeOptions sets the options in the sorted order of option names.
+// Previous values are captured before setting any option, if any set
+// fails then the applied options are restored in reverse order. Options
+// which were not set before are reset to default. Error is returned only
+// if it fails before setting any option.
+func setVolumeOptions(volName string, opts map[string]string) (volumeOptionsSetResult, error) {
+	result := volumeOptionsSetResult{Applied: []string{}, RolledBack: []string{}}
+
+	var keys []string
+	for k := range opts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// Previous values are captured using the option names resolved by
+	// glusterd, so that the options specified without the translator
+	// prefix are restored correctly. Options not set in the Volume
+	// are reset during rollback. No option is set if any previous
+	// value can't be captured, since it can't be rolled back
+	prevValues := make(map[string]cli.VolumeOptionInfo)
+	for _, k := range keys {
+		info, err := cli.VolumeOptInfo(volName, k)
+		if err == cli.ErrOptionNotFound {
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		prevValues[k] = info
+	}
+
+	var errSet error
+	for _, k := range keys {
+		errSet = cli.VolumeOptSet(volName, k, opts[k])
+		if errSet != nil {
+			result.Message = k + ": " + errSet.Error()
+			break
+		}
+		result.Applied = append(result.Applied, k)
+	}
+	if errSet == nil {
+		result.Ok = true
+		return result, nil
+	}
+
+	for i := len(result.Applied) - 1; i >= 0; i-- {
+		k := result.Applied[i]
+		name := k
+		prev, ok := prevValues[k]
+		if ok {
+			name = prev.Name
+		}
+
+		var err error
+		if ok && prev.ExplicitlySet {
+			err = cli.VolumeOptSet(volName, name, prev.Value)
+		} else {
+			err = cli.VolumeOptReset(volName, name, false)
+		}
+		if err != nil {
+			utils.Logger.Error("Failed to rollback option ", name, " of Volume ", volName, ": ", err)
+			result.RollbackFailed = append(result.RollbackFailed, k)
+			continue
+		}
+		result.RolledBack = append(result.RolledBack, k)
+	}
+	return result, nil
+}
+
+// OptionsCatalogGet is a HTTP handler to get the catalog of Volume
+// options with description, type, default value and allowed range
+func OptionsCatalogGet(w http.ResponseWriter, r *http.Request) {
+	list, _, err := loadOptionCatalog()
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, list)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"gluster/cli"
+	"gluster/utils"
+)
+
+// VolumeProfileStart is a HTTP handler to start profiling a Gluster Volume
+func VolumeProfileStart(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	err := cli.VolumeProfileStart(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// VolumeProfileStop is a HTTP handler to stop profiling a Gluster Volume
+func VolumeProfileStop(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	err := cli.VolumeProfileStop(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// VolumeProfileInfo is a HTTP handler to get profile stats of each Brick
+// of a Gluster Volume. Use type=peek|incremental|cumulative to get
+// specific stats, both cumulative and interval stats are returned by
+// default
+func VolumeProfileInfo(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	infoType := r.URL.Query().Get("type")
+	if infoType != "" && !utils.StringInList(infoType, cli.ProfileInfoTypes) {
+		utils.HTTPErrorJSON(w, "Invalid profile info type: "+infoType, http.StatusBadRequest)
+		return
+	}
+
+	info, err := cli.VolumeProfileInfo(volName, infoType)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
+
+// VolumeTop is a HTTP handler to get top stats of a Gluster Volume. Query
+// parameters brick and list_count are supported for all metrics, bs
+// and count are supported for read-perf and write-perf
+func VolumeTop(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	metric := vars["metric"]
+	if !utils.StringInList(metric, cli.TopMetrics) {
+		utils.HTTPErrorJSON(w, "Invalid top metric: "+metric, http.StatusBadRequest)
+		return
+	}
+
+	opts := cli.TopOptions{Brick: r.URL.Query().Get("brick")}
+	intParams := map[string]*int{
+		"list_count": &opts.ListCount,
+		"bs":         &opts.BlockSize,
+		"count":      &opts.Count,
+	}
+	for name, value := range intParams {
+		v := r.URL.Query().Get(name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			utils.HTTPErrorJSON(w, "Invalid "+name+": "+v, http.StatusBadRequest)
+			return
+		}
+		*value = n
+	}
+
+	info, err := cli.VolumeTop(volName, metric, opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"gluster/cli"
+	"gluster/utils"
+)
+
+// optionDiff - Difference between current value of a Volume option and
+// the value in profile
+type optionDiff struct {
+	Name    string `json:"name"`
+	Current string `json:"current"`
+	Profile string `json:"profile"`
+}
+
+var errProfileNotFound = errors.New("Profile does not exist")
+
+// normalizeOptionValue returns the value in a form which can be compared,
+// volume get shows the default values with "(DEFAULT)" suffix and
+// boolean options accept many aliases
+func normalizeOptionValue(value string) string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSpace(strings.TrimSuffix(value, "(DEFAULT)"))
+	value = strings.ToLower(value)
+	switch value {
+	case "on", "enable", "true", "yes", "1":
+		return "on"
+	case "off", "disable", "false", "no", "0":
+		return "off"
+	}
+	return value
+}
+
+func profileGet(name string) (utils.OptionsProfile, error) {
+	profiles, err := utils.LoadOptionsProfiles()
+	if err != nil {
+		return utils.OptionsProfile{}, err
+	}
+	profile, ok := profiles[name]
+	if !ok {
+		return utils.OptionsProfile{}, errProfileNotFound
+	}
+	return profile, nil
+}
+
+func profileErrorJSON(w http.ResponseWriter, err error) {
+	if err == errProfileNotFound {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+}
+
+// VolumeOptionsGroupSet is a HTTP handler to apply the Gluster option
+// group(For example, virt, db-workload or metadata-cache) to a Volume
+func VolumeOptionsGroupSet(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	group := vars["group"]
+	err := cli.VolumeOptSetGroup(volName, group)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// ProfilesGet is a HTTP handler to get the list of custom options profiles
+func ProfilesGet(w http.ResponseWriter, r *http.Request) {
+	profiles, err := utils.LoadOptionsProfiles()
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var names []string
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := []utils.OptionsProfile{}
+	for _, name := range names {
+		out = append(out, profiles[name])
+	}
+	utils.HTTPOutJSON(w, out)
+}
+
+// ProfileSet is a HTTP handler to create or update a custom options
+// profile. Options are validated using the option catalog
+func ProfileSet(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var profile utils.OptionsProfile
+	err := decoder.Decode(&profile)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(profile.Options) == 0 {
+		utils.HTTPErrorJSON(w, "Profile should have at least one option", http.StatusBadRequest)
+		return
+	}
+
+	fieldErrors, err := validateVolumeOptions(profile.Options)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(fieldErrors) > 0 {
+		utils.HTTPFieldErrorsJSON(w, "Invalid Volume options", fieldErrors, http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	profile.Name = vars["name"]
+	errUpdate := utils.UpdateOptionsProfiles(func(profiles utils.OptionsProfiles) error {
+		profiles[profile.Name] = profile
+		return nil
+	})
+	if errUpdate != nil {
+		utils.HTTPErrorJSON(w, errUpdate.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, profile)
+}
+
+// ProfileDelete is a HTTP handler to delete a custom options profile
+func ProfileDelete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+	err := utils.UpdateOptionsProfiles(func(profiles utils.OptionsProfiles) error {
+		if _, ok := profiles[name]; !ok {
+			return errProfileNotFound
+		}
+		delete(profiles, name)
+		return nil
+	})
+	if err != nil {
+		profileErrorJSON(w, err)
+		return
+	}
+}
+
+// VolumeProfileDiff is a HTTP handler to get the options of a custom
+// profile whose value differs from the current value in the Volume
+func VolumeProfileDiff(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	profile, err := profileGet(vars["name"])
+	if err != nil {
+		profileErrorJSON(w, err)
+		return
+	}
+
+	_, options, err := loadOptionCatalog()
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	current, err := cli.VolumeOptGet(volName, "all")
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	currentValues := make(map[string]string)
+	for _, o := range current {
+		currentValues[o.Name] = o.Value
+	}
+
+	var keys []string
+	for k := range profile.Options {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	diff := []optionDiff{}
+	for _, k := range keys {
+		name := k
+		if opt, ok := catalogLookup(options, k); ok {
+			name = opt.Name
+		}
+		if normalizeOptionValue(currentValues[name]) != normalizeOptionValue(profile.Options[k]) {
+			diff = append(diff, optionDiff{Name: name, Current: currentValues[name], Profile: profile.Options[k]})
+		}
+	}
+	utils.HTTPOutJSON(w, diff)
+}
+
+// VolumeProfileApply is a HTTP handler to apply a custom options profile
+// to a Volume. Options are set all or nothing same as Volume options set
+func VolumeProfileApply(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	profile, err := profileGet(vars["name"])
+	if err != nil {
+		profileErrorJSON(w, err)
+		return
+	}
+
+	result, err := setVolumeOptions(volName, profile.Options)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !result.Ok {
+		utils.HTTPOutJSONStatus(w, result, http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, result)
+}
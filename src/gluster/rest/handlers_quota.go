@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"gluster/cli"
+	"gluster/utils"
+)
+
+// VolumeQuotaEnable is a HTTP handler to enable Quota of a Gluster Volume
+func VolumeQuotaEnable(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	err := cli.VolumeQuotaEnable(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// VolumeQuotaDisable is a HTTP handler to disable Quota of a Gluster Volume
+func VolumeQuotaDisable(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	err := cli.VolumeQuotaDisable(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// VolumeQuotaLimitsGet is a HTTP handler to list the Quota limits of a
+// Gluster Volume. Use objects=1 to list the object count limits and
+// path=<PATH> to list only the limits of given directories
+func VolumeQuotaLimitsGet(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	paths := r.URL.Query()["path"]
+	if r.URL.Query().Get("objects") == "1" {
+		info, err := cli.VolumeQuotaListObjects(volName, paths)
+		if err != nil {
+			utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		utils.HTTPOutJSON(w, info)
+		return
+	}
+
+	info, err := cli.VolumeQuotaList(volName, paths)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
+
+// VolumeQuotaLimitsSet is a HTTP handler to set usage and/or object count
+// limit of a directory
+func VolumeQuotaLimitsSet(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var opts cli.QuotaLimitOptions
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if opts.Path == "" {
+		utils.HTTPErrorJSON(w, "Path is required", http.StatusBadRequest)
+		return
+	}
+	if opts.Size == "" && opts.Objects == 0 {
+		utils.HTTPErrorJSON(w, "size or objects limit is required", http.StatusBadRequest)
+		return
+	}
+	if opts.Objects < 0 || opts.SoftLimit < 0 || opts.SoftLimit > 100 {
+		utils.HTTPErrorJSON(w, "Invalid objects or soft_limit", http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	if opts.Size != "" {
+		err := cli.VolumeQuotaLimitUsage(volName, opts.Path, opts.Size, opts.SoftLimit)
+		if err != nil {
+			utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if opts.Objects != 0 {
+		err := cli.VolumeQuotaLimitObjects(volName, opts.Path, opts.Objects, opts.SoftLimit)
+		if err != nil {
+			utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// VolumeQuotaLimitsRemove is a HTTP handler to remove usage limits of the
+// given list of directories. Use objects=1 to remove the object count
+// limits instead
+func VolumeQuotaLimitsRemove(w http.ResponseWriter, r *http.Request) {
+	var paths []string
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&paths)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	objects := r.URL.Query().Get("objects") == "1"
+	for _, p := range paths {
+		var err error
+		if objects {
+			err = cli.VolumeQuotaRemoveObjects(volName, p)
+		} else {
+			err = cli.VolumeQuotaRemove(volName, p)
+		}
+		if err != nil {
+			utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
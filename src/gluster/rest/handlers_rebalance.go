@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"gluster/cli"
+	"gluster/utils"
+)
+
+// VolumeRebalanceStart is a HTTP handler to start rebalance of a Gluster
+// Volume. Use fix_layout=1 to only fix the layout and force=1 to
+// migrate data irrespective of the free space on the destination
+func VolumeRebalanceStart(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	fixLayout := r.URL.Query().Get("fix_layout") == "1"
+	force := r.URL.Query().Get("force") == "1"
+	if fixLayout && force {
+		utils.HTTPErrorJSON(w, "fix_layout and force can't be used together", http.StatusBadRequest)
+		return
+	}
+
+	err := cli.VolumeRebalanceStart(volName, fixLayout, force)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// VolumeRebalanceStatus is a HTTP handler to get rebalance progress of
+// a Gluster Volume
+func VolumeRebalanceStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	info, err := cli.VolumeRebalanceStatus(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
+
+// VolumeRebalanceStop is a HTTP handler to stop rebalance of a Gluster Volume
+func VolumeRebalanceStop(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	err := cli.VolumeRebalanceStop(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,509 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/gorilla/mux"
+	"github.com/robfig/cron"
+	"gluster/cli"
+	"gluster/utils"
+)
+
+// Snapshot schedule name is used as the name of scheduled Snapshots,
+// Gluster appends the timestamp to it
+var snapScheduleNameRe = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,200}$`)
+
+// Snapshot configurations which can be set cluster wide and per Volume
+var snapConfigKeys = []string{"snap-max-hard-limit", "snap-max-soft-limit", "auto-delete", "activate-on-create"}
+var snapVolumeConfigKeys = []string{"snap-max-hard-limit"}
+
+// snapshotVolume returns the name of the Volume from which the Snapshot
+// is taken
+func snapshotVolume(snap cli.Snapshot) string {
+	for _, v := range snap.Volumes {
+		if v.OriginVolume.Name != "" {
+			return v.OriginVolume.Name
+		}
+	}
+	return ""
+}
+
+// snapshotAllowed responds with error and returns false if the Volume
+// of the Snapshot is not in the scope of the App of request
+func snapshotAllowed(w http.ResponseWriter, r *http.Request, snapName string) bool {
+	app, ok := requestApp(r)
+	if !ok || !app.VolumeScoped() {
+		return true
+	}
+
+	snaps, err := cli.SnapshotInfo(snapName, "")
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	if len(snaps) == 0 || !app.VolumeAllowed(snapshotVolume(snaps[0])) {
+		utils.HTTPErrorJSON(w, "Permission denied, Snapshot "+snapName+" is not in the scope of App", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// snapScheduleAllowed responds with error and returns false if the
+// Volume of an existing Snapshot schedule is not in the scope of the
+// App of request
+func snapScheduleAllowed(w http.ResponseWriter, r *http.Request, name string) bool {
+	app, ok := requestApp(r)
+	if !ok || !app.VolumeScoped() {
+		return true
+	}
+
+	schedules, err := utils.LoadSnapSchedules()
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	if schedule, ok := schedules[name]; ok && !app.VolumeAllowed(schedule.Volume) {
+		utils.HTTPErrorJSON(w, "Permission denied, Snapshot schedule "+name+" is not in the scope of App", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// SnapshotCreate is a Handler function to create Snapshot of a Gluster
+// Volume. Responds with the information of created Snapshot
+func SnapshotCreate(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var opts cli.SnapCreateOptions
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if opts.Volume == "" {
+		utils.HTTPErrorJSON(w, "Volume name is required", http.StatusBadRequest)
+		return
+	}
+	if !volumeAllowed(w, r, opts.Volume) {
+		return
+	}
+
+	vars := mux.Vars(r)
+	snapName := vars["snapName"]
+	name, errCreate := cli.SnapshotCreate(snapName, opts.Volume, opts)
+	if errCreate != nil {
+		utils.HTTPErrorJSON(w, errCreate.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	info, err := cli.SnapshotInfo(name, "")
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
+
+// SnapshotGet is a HTTP Handler function to get Snapshot Information. Use
+// volume=<VOLNAME> to list Snapshots of a Volume and status=1 to get
+// the Brick status of Snapshots. Apps limited to some Volumes get only
+// the Snapshots of those Volumes
+func SnapshotGet(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	snapName, ok := vars["snapName"]
+	if !ok {
+		snapName = ""
+	}
+	volName := r.URL.Query().Get("volume")
+	if volName != "" && !volumeAllowed(w, r, volName) {
+		return
+	}
+	if snapName != "" && !snapshotAllowed(w, r, snapName) {
+		return
+	}
+
+	// Snapshots list is filtered only if the Snapshot or Volume is
+	// not specified, they are already checked above
+	app, scoped := requestApp(r)
+	scoped = scoped && app.VolumeScoped() && snapName == "" && volName == ""
+
+	var snaps []cli.Snapshot
+	var err error
+	if scoped || r.URL.Query().Get("status") != "1" {
+		snaps, err = cli.SnapshotInfo(snapName, volName)
+		if err != nil {
+			utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	var allowed []string
+	if scoped {
+		filtered := []cli.Snapshot{}
+		for _, snap := range snaps {
+			if app.VolumeAllowed(snapshotVolume(snap)) {
+				filtered = append(filtered, snap)
+				allowed = append(allowed, snap.Name)
+			}
+		}
+		snaps = filtered
+	}
+
+	if r.URL.Query().Get("status") == "1" {
+		info, err := cli.SnapshotStatus(snapName, volName)
+		if err != nil {
+			utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if scoped {
+			filtered := []cli.SnapStatus{}
+			for _, st := range info {
+				if utils.StringInList(st.Name, allowed) {
+					filtered = append(filtered, st)
+				}
+			}
+			info = filtered
+		}
+		utils.HTTPOutJSON(w, info)
+		return
+	}
+	utils.HTTPOutJSON(w, snaps)
+}
+
+// SnapshotActivate is a HTTP handler to activate a Snapshot
+func SnapshotActivate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	snapName := vars["snapName"]
+	if !snapshotAllowed(w, r, snapName) {
+		return
+	}
+	force := r.URL.Query().Get("force") == "1"
+	err := cli.SnapshotActivate(snapName, force)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// SnapshotDeactivate is a HTTP handler to deactivate a Snapshot
+func SnapshotDeactivate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	snapName := vars["snapName"]
+	if !snapshotAllowed(w, r, snapName) {
+		return
+	}
+	err := cli.SnapshotDeactivate(snapName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// SnapshotRestore is a HTTP handler to restore a Volume from Snapshot
+func SnapshotRestore(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	snapName := vars["snapName"]
+	if !snapshotAllowed(w, r, snapName) {
+		return
+	}
+	err := cli.SnapshotRestore(snapName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// SnapshotDelete is a HTTP handler to delete a Snapshot
+func SnapshotDelete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	snapName := vars["snapName"]
+	if !snapshotAllowed(w, r, snapName) {
+		return
+	}
+	err := cli.SnapshotDelete(snapName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// snapshotActivated returns true if all the Snapshot Volumes are
+// started, Snapshot without Volumes is treated as not activated
+func snapshotActivated(snap cli.Snapshot) bool {
+	if len(snap.Volumes) == 0 {
+		return false
+	}
+	for _, v := range snap.Volumes {
+		if v.Status != "Started" {
+			return false
+		}
+	}
+	return true
+}
+
+// SnapshotClone is a HTTP handler to create a new Volume from an activated
+// Snapshot and optionally start it. Responds with the new Volume
+// information same as Volume Get
+func SnapshotClone(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var opts cli.SnapCloneOptions
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if opts.Name == "" {
+		utils.HTTPErrorJSON(w, "Clone name is required", http.StatusBadRequest)
+		return
+	}
+	if !volumeCreateAllowed(w, r, opts.Name) {
+		return
+	}
+
+	vars := mux.Vars(r)
+	snapName := vars["snapName"]
+	snaps, err := cli.SnapshotInfo(snapName, "")
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(snaps) == 0 {
+		utils.HTTPErrorJSON(w, "Snapshot does not exist", http.StatusNotFound)
+		return
+	}
+	if !volumeAllowed(w, r, snapshotVolume(snaps[0])) {
+		return
+	}
+	if !snapshotActivated(snaps[0]) {
+		utils.HTTPErrorJSON(w, fmt.Sprintf("Snapshot %s is not activated", snapName), http.StatusConflict)
+		return
+	}
+
+	errClone := cli.SnapshotClone(opts.Name, snapName)
+	if errClone != nil {
+		utils.HTTPErrorJSON(w, errClone.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if opts.Start {
+		errStart := cli.VolumeStart(opts.Name, false)
+		if errStart != nil {
+			utils.HTTPErrorJSON(w, errStart.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	info, err := cli.VolumeInfo(opts.Name)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
+
+// SnapshotConfigGet is a HTTP handler to get Snapshot configuration,
+// cluster wide or of a Volume if volName is set. Apps limited to some
+// Volumes get the configuration of only those Volumes
+func SnapshotConfigGet(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName, ok := vars["volName"]
+	if !ok {
+		volName = ""
+	}
+	info, err := cli.SnapshotConfigGet(volName)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if app, ok := requestApp(r); ok && app.VolumeScoped() {
+		volumes := []cli.SnapVolumeConfig{}
+		for _, v := range info.Volumes {
+			if app.VolumeAllowed(v.Name) {
+				volumes = append(volumes, v)
+			}
+		}
+		info.Volumes = volumes
+	}
+	utils.HTTPOutJSON(w, info)
+}
+
+// SnapshotConfigSet is a HTTP handler to set Snapshot configuration,
+// cluster wide or of a Volume if volName is set. Only
+// snap-max-hard-limit can be set per Volume.
+func SnapshotConfigSet(w http.ResponseWriter, r *http.Request) {
+	var opts = make(map[string]string)
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	volName, ok := vars["volName"]
+	allowedKeys := snapVolumeConfigKeys
+	if !ok {
+		volName = ""
+		allowedKeys = snapConfigKeys
+	}
+
+	var keys []string
+	for k := range opts {
+		if !utils.StringInList(k, allowedKeys) {
+			utils.HTTPErrorJSON(w, "Invalid Snapshot config: "+k, http.StatusBadRequest)
+			return
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		err := cli.SnapshotConfigSet(volName, k, opts[k])
+		if err != nil {
+			utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// SnapScheduleGet is a HTTP handler to get the list of Snapshot
+// schedules. Apps limited to some Volumes get the schedules of only
+// those Volumes
+func SnapScheduleGet(w http.ResponseWriter, r *http.Request) {
+	schedules, err := utils.LoadSnapSchedules()
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	app, ok := requestApp(r)
+	var names []string
+	for name, schedule := range schedules {
+		if ok && !app.VolumeAllowed(schedule.Volume) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := []utils.SnapSchedule{}
+	for _, name := range names {
+		out = append(out, schedules[name])
+	}
+	utils.HTTPOutJSON(w, out)
+}
+
+// SnapScheduleSet is a HTTP handler to create or update a Snapshot
+// schedule. Schedule is a cron expression, for example "0 */6 * * *"
+func SnapScheduleSet(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var schedule utils.SnapSchedule
+	err := decoder.Decode(&schedule)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	schedule.Name = vars["name"]
+	if !snapScheduleNameRe.MatchString(schedule.Name) {
+		utils.HTTPErrorJSON(w, "Invalid schedule name, should contain only letters, digits, '_', '-' or '.' and be at most 200 characters", http.StatusBadRequest)
+		return
+	}
+	if schedule.Volume == "" {
+		utils.HTTPErrorJSON(w, "Volume name is required", http.StatusBadRequest)
+		return
+	}
+	if !volumeAllowed(w, r, schedule.Volume) || !snapScheduleAllowed(w, r, schedule.Name) {
+		return
+	}
+	if schedule.Retention < 0 {
+		utils.HTTPErrorJSON(w, "Retention should not be negative", http.StatusBadRequest)
+		return
+	}
+	_, errParse := cron.ParseStandard(schedule.Schedule)
+	if errParse != nil {
+		utils.HTTPErrorJSON(w, "Invalid schedule: "+errParse.Error(), http.StatusBadRequest)
+		return
+	}
+
+	volumes, err := cli.VolumeList()
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !utils.StringInList(schedule.Volume, volumes) {
+		utils.HTTPErrorJSON(w, "Volume does not exist", http.StatusBadRequest)
+		return
+	}
+
+	errUpdate := utils.UpdateSnapSchedules(func(schedules utils.SnapSchedules) error {
+		schedules[schedule.Name] = schedule
+		return nil
+	})
+	if errUpdate != nil {
+		utils.HTTPErrorJSON(w, errUpdate.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, schedule)
+}
+
+// SnapScheduleDelete is a HTTP handler to delete a Snapshot schedule,
+// Snapshots created by the schedule are not deleted
+func SnapScheduleDelete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+	if !snapScheduleAllowed(w, r, name) {
+		return
+	}
+	errNotFound := errors.New("Snapshot schedule does not exist")
+	err := utils.UpdateSnapSchedules(func(schedules utils.SnapSchedules) error {
+		if _, ok := schedules[name]; !ok {
+			return errNotFound
+		}
+		delete(schedules, name)
+		return nil
+	})
+	if err == errNotFound {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// History is not required once the schedule is deleted
+	errHistory := utils.UpdateSnapSchedulesHistory(func(history utils.SnapSchedulesHistory) error {
+		delete(history, name)
+		return nil
+	})
+	if errHistory != nil {
+		utils.Logger.Error("Unable to delete Snapshot schedule history ", name, ": ", errHistory)
+	}
+}
+
+// SnapScheduleRuns is a HTTP handler to get the recent runs of a Snapshot
+// schedule
+func SnapScheduleRuns(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if !snapScheduleAllowed(w, r, vars["name"]) {
+		return
+	}
+	history, err := utils.LoadSnapSchedulesHistory()
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	runs := history[vars["name"]].Runs
+	if runs == nil {
+		runs = []utils.SnapScheduleRun{}
+	}
+	utils.HTTPOutJSON(w, runs)
+}
@@ -21,6 +21,10 @@ func VolumeCreate(w http.ResponseWriter, r *http.Request) {
 
 	vars := mux.Vars(r)
 	volName := vars["volName"]
+	if !volumeCreateAllowed(w, r, volName) {
+		return
+	}
+
 	errCreate := cli.VolumeCreate(volName, opts.Bricks, opts)
 	if errCreate != nil {
 		utils.HTTPErrorJSON(w, errCreate.Error(), http.StatusInternalServerError)
@@ -46,6 +50,17 @@ func VolumeGet(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
 	}
+
+	// List only the Volumes in the scope of App
+	if app, ok := requestApp(r); ok && app.VolumeScoped() {
+		allowed := []cli.Volume{}
+		for _, v := range info {
+			if app.VolumeAllowed(v.Name) {
+				allowed = append(allowed, v)
+			}
+		}
+		info = allowed
+	}
 	utils.HTTPOutJSON(w, info)
 }
 
@@ -64,6 +79,25 @@ func VolumeStatus(w http.ResponseWriter, r *http.Request) {
 	utils.HTTPOutJSON(w, info)
 }
 
+// VolumeStatusView is a HTTP Handler function to get Gluster Volume
+// Status of a view(clients, mem, inode, fd, callpool or tasks)
+func VolumeStatusView(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	view := vars["view"]
+	if !utils.StringInList(view, cli.StatusViews) {
+		utils.HTTPErrorJSON(w, "Invalid Volume status view: "+view, http.StatusBadRequest)
+		return
+	}
+
+	info, err := cli.VolumeStatusView(volName, view)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
+
 // VolumeStart is a HTTP handler to Start Gluster Volume
 func VolumeStart(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -120,6 +154,27 @@ func VolumeOptionsGet(w http.ResponseWriter, r *http.Request) {
 	utils.HTTPOutJSON(w, info)
 }
 
+// VolumeOptionGet is a HTTP handler func to get the effective value of a
+// Volume option and whether it is explicitly set on the Volume
+func VolumeOptionGet(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	volName := vars["volName"]
+	key := vars["key"]
+	info, err := cli.VolumeOptInfo(volName, key)
+	if err == cli.ErrOptionNotFound {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, info)
+}
+
+// VolumeOptionsSet is a HTTP handler func to set Volume options. Options
+// are set all or nothing, responds with the list of applied options
+// and the list of options rolled back if any option set fails
 func VolumeOptionsSet(w http.ResponseWriter, r *http.Request) {
 	var opts = make(map[string]string)
 	decoder := json.NewDecoder(r.Body)
@@ -129,15 +184,28 @@ func VolumeOptionsSet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	fieldErrors, err := validateVolumeOptions(opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(fieldErrors) > 0 {
+		utils.HTTPFieldErrorsJSON(w, "Invalid Volume options", fieldErrors, http.StatusBadRequest)
+		return
+	}
+
 	vars := mux.Vars(r)
 	volName := vars["volName"]
-	for k, v := range opts {
-		err := cli.VolumeOptSet(volName, k, v)
-		if err != nil {
-			utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
+	result, err := setVolumeOptions(volName, opts)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !result.Ok {
+		utils.HTTPOutJSONStatus(w, result, http.StatusInternalServerError)
+		return
 	}
+	utils.HTTPOutJSON(w, result)
 }
 
 func VolumeOptionsReset(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"gluster/utils"
+)
+
+// Secrets are not returned in the API responses
+const secretMask = "********"
+
+var errWebhookNotFound = errors.New("Webhook does not exist")
+
+// webhookRequest - Request to create or update a webhook. BearerToken
+// and Secret are not changed during update if not specified, set to
+// empty string to remove
+type webhookRequest struct {
+	URL         string   `json:"url"`
+	Events      []string `json:"events"`
+	Volumes     []string `json:"volumes"`
+	BearerToken *string  `json:"bearer_token"`
+	Secret      *string  `json:"secret"`
+}
+
+func (req webhookRequest) Validate() map[string]string {
+	errs := make(map[string]string)
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs["url"] = "should be a valid http or https URL"
+	}
+	for _, e := range req.Events {
+		if !eventNameRe.MatchString(strings.ToUpper(e)) {
+			errs["events"] = "invalid Event name " + e
+			break
+		}
+	}
+	return errs
+}
+
+// Apply updates the webhook with the values from request
+func (req webhookRequest) Apply(webhook *utils.Webhook) {
+	webhook.URL = req.URL
+	webhook.Events = []string{}
+	for _, e := range req.Events {
+		webhook.Events = append(webhook.Events, strings.ToUpper(e))
+	}
+	webhook.Volumes = req.Volumes
+	if webhook.Volumes == nil {
+		webhook.Volumes = []string{}
+	}
+	if req.BearerToken != nil {
+		webhook.BearerToken = *req.BearerToken
+	}
+	if req.Secret != nil {
+		webhook.Secret = *req.Secret
+	}
+}
+
+func webhookMasked(webhook utils.Webhook) utils.Webhook {
+	if webhook.BearerToken != "" {
+		webhook.BearerToken = secretMask
+	}
+	if webhook.Secret != "" {
+		webhook.Secret = secretMask
+	}
+	return webhook
+}
+
+func webhookRequestDecode(w http.ResponseWriter, r *http.Request) (webhookRequest, bool) {
+	decoder := json.NewDecoder(r.Body)
+	var req webhookRequest
+	err := decoder.Decode(&req)
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return req, false
+	}
+
+	fieldErrors := req.Validate()
+	if len(fieldErrors) > 0 {
+		utils.HTTPFieldErrorsJSON(w, "Invalid webhook", fieldErrors, http.StatusBadRequest)
+		return req, false
+	}
+	return req, true
+}
+
+func webhookErrorJSON(w http.ResponseWriter, err error) {
+	if err == errWebhookNotFound {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+}
+
+// webhookInScope checks if the webhook is visible to the App. Apps
+// limited to some Volumes can see only the webhooks of those Volumes,
+// so they receive only the Events of their Volumes
+func webhookInScope(r *http.Request, webhook utils.Webhook) bool {
+	app, ok := requestApp(r)
+	if !ok || !app.VolumeScoped() {
+		return true
+	}
+	if len(webhook.Volumes) == 0 {
+		return false
+	}
+	for _, v := range webhook.Volumes {
+		if !app.VolumeAllowed(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// webhookVolumesAllowed responds with error and returns false if the
+// webhook Volumes are not in the scope of the App of request
+func webhookVolumesAllowed(w http.ResponseWriter, r *http.Request, req webhookRequest) bool {
+	app, ok := requestApp(r)
+	if !ok || !app.VolumeScoped() {
+		return true
+	}
+	if len(req.Volumes) == 0 {
+		utils.HTTPErrorJSON(w, "Permission denied, volumes are required for the App limited to Volumes", http.StatusForbidden)
+		return false
+	}
+	for _, v := range req.Volumes {
+		if !volumeAllowed(w, r, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// webhookGet returns the webhook, webhooks not in the scope of the
+// App of request are treated as not existing
+func webhookGet(r *http.Request, id string) (utils.Webhook, error) {
+	webhooks, err := utils.LoadWebhooks()
+	if err != nil {
+		return utils.Webhook{}, err
+	}
+	webhook, ok := webhooks[id]
+	if !ok || !webhookInScope(r, webhook) {
+		return utils.Webhook{}, errWebhookNotFound
+	}
+	return webhook, nil
+}
+
+// WebhookCreate is a HTTP handler to create the webhook subscription
+func WebhookCreate(w http.ResponseWriter, r *http.Request) {
+	req, ok := webhookRequestDecode(w, r)
+	if !ok || !webhookVolumesAllowed(w, r, req) {
+		return
+	}
+
+	webhook := utils.Webhook{ID: randomID(), CreatedAt: time.Now().UTC()}
+	req.Apply(&webhook)
+	err := utils.UpdateWebhooks(func(webhooks utils.Webhooks) error {
+		webhooks[webhook.ID] = webhook
+		return nil
+	})
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, webhookMasked(webhook))
+}
+
+// WebhookUpdate is a HTTP handler to update the webhook subscription
+func WebhookUpdate(w http.ResponseWriter, r *http.Request) {
+	req, ok := webhookRequestDecode(w, r)
+	if !ok || !webhookVolumesAllowed(w, r, req) {
+		return
+	}
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	var webhook utils.Webhook
+	err := utils.UpdateWebhooks(func(webhooks utils.Webhooks) error {
+		var ok bool
+		webhook, ok = webhooks[id]
+		if !ok || !webhookInScope(r, webhook) {
+			return errWebhookNotFound
+		}
+		req.Apply(&webhook)
+		webhooks[id] = webhook
+		return nil
+	})
+	if err != nil {
+		webhookErrorJSON(w, err)
+		return
+	}
+	utils.HTTPOutJSON(w, webhookMasked(webhook))
+}
+
+// WebhooksGet is a HTTP handler to get the list of webhook subscriptions,
+// Apps limited to some Volumes get the webhooks of only those Volumes
+func WebhooksGet(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := utils.LoadWebhooks()
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var ids []string
+	for id, webhook := range webhooks {
+		if webhookInScope(r, webhook) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	out := []utils.Webhook{}
+	for _, id := range ids {
+		out = append(out, webhookMasked(webhooks[id]))
+	}
+	utils.HTTPOutJSON(w, out)
+}
+
+// WebhookGet is a HTTP handler to get the webhook subscription
+func WebhookGet(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	webhook, err := webhookGet(r, vars["id"])
+	if err != nil {
+		webhookErrorJSON(w, err)
+		return
+	}
+	utils.HTTPOutJSON(w, webhookMasked(webhook))
+}
+
+// WebhookDelete is a HTTP handler to delete the webhook subscription
+func WebhookDelete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	err := utils.UpdateWebhooks(func(webhooks utils.Webhooks) error {
+		if webhook, ok := webhooks[id]; !ok || !webhookInScope(r, webhook) {
+			return errWebhookNotFound
+		}
+		delete(webhooks, id)
+		return nil
+	})
+	if err != nil {
+		webhookErrorJSON(w, err)
+		return
+	}
+	err = webhookDeliveriesDelete(id)
+	if err != nil && err != errEventsStoreNotAvailable {
+		utils.Logger.Error("Unable to delete deliveries of webhook ", id, ": ", err)
+	}
+}
+
+// WebhookDeliveriesGet is a HTTP handler to get the recent delivery
+// attempts of the webhook from this node, latest first
+func WebhookDeliveriesGet(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	if _, err := webhookGet(r, id); err != nil {
+		webhookErrorJSON(w, err)
+		return
+	}
+	out, err := webhookDeliveriesGet(id)
+	if err == errEventsStoreNotAvailable {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, out)
+}
+
+// WebhookDeadLettersGet is a HTTP handler to get the Events which could
+// not be delivered to the webhook from this node
+func WebhookDeadLettersGet(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	if _, err := webhookGet(r, id); err != nil {
+		webhookErrorJSON(w, err)
+		return
+	}
+	out, err := webhookDeadLettersGet(id)
+	if err == errEventsStoreNotAvailable {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.HTTPOutJSON(w, out)
+}
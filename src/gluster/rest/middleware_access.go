@@ -0,0 +1,189 @@
+package main
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"gluster/utils"
+)
+
+// Permissions are "<METHOD> <ROUTE>" patterns matched against the route
+// template. METHOD can be "*" for any method. ROUTE is matched using
+// path.Match, "/**" at the end matches the route and all its sub routes.
+// For example: "GET /v1/volumes/**", "* /v1/volumes/{volName}/heal"
+var rolePermissions = map[string][]string{
+	utils.RoleViewer:   {"GET /**"},
+	utils.RoleOperator: {"* /**"},
+	utils.RoleAdmin:    {"* /**"},
+}
+
+// Operations which change the Cluster membership, configuration,
+// replace the bricks or delete the data are not allowed for operator
+// role. Resolving split-brain discards the other copies of the file.
+// Snapshot config is denied for both Cluster and Volume since it can
+// enable auto-delete of the Snapshots
+var operatorDenied = []string{
+	"PUT /v1/volumes/{volName}",
+	"DELETE /v1/volumes/{volName}",
+	"POST /v1/volumes/{volName}/bricks/remove/commit",
+	"* /v1/volumes/{volName}/bricks/{brick:.+}",
+	"POST /v1/volumes/{volName}/heal/split-brain",
+	"DELETE /v1/volumes/{volName}/quota",
+	"PUT /v1/options/profiles/{name}",
+	"DELETE /v1/options/profiles/{name}",
+	"PUT /v1/volumes/{volName}/georep/{remoteHost}/{remoteVol}",
+	"DELETE /v1/volumes/{volName}/georep/{remoteHost}/{remoteVol}",
+	"POST /v1/snapshots/{snapName}/restore",
+	"DELETE /v1/snapshots/{snapName}",
+	"POST /v1/snapshots/config",
+	"POST /v1/volumes/{volName}/snapshots/config",
+	"POST /v1/peers",
+	"DELETE /v1/peers",
+	"POST /v1/cluster/**",
+	"DELETE /v1/cluster/**",
+	"POST /v1/webhooks/**",
+	"PUT /v1/webhooks/**",
+	"DELETE /v1/webhooks/**",
+}
+
+// Cluster wide operations are not allowed for the Apps limited to some
+// Volumes, since they affect the Volumes outside the scope of App
+var scopedDenied = []string{
+	"* /v1/peers",
+	"POST /v1/cluster/**",
+	"DELETE /v1/cluster/**",
+	"POST /v1/snapshots/config",
+	"PUT /v1/options/profiles/{name}",
+	"DELETE /v1/options/profiles/{name}",
+}
+
+func permissionMatch(pattern string, method string, route string) bool {
+	parts := strings.SplitN(strings.TrimSpace(pattern), " ", 2)
+	if len(parts) != 2 {
+		return false
+	}
+
+	patMethod, patRoute := parts[0], strings.TrimSpace(parts[1])
+	if patMethod != "*" && !strings.EqualFold(patMethod, method) {
+		return false
+	}
+
+	if strings.HasSuffix(patRoute, "/**") {
+		prefix := strings.TrimSuffix(patRoute, "/**")
+		return route == prefix || strings.HasPrefix(route, prefix+"/")
+	}
+	matched, err := path.Match(patRoute, route)
+	return err == nil && matched
+}
+
+func permissionsMatch(patterns []string, method string, route string) bool {
+	for _, p := range patterns {
+		if permissionMatch(p, method, route) {
+			return true
+		}
+	}
+	return false
+}
+
+// appAllowed checks if the App has permission for the method and route
+func appAllowed(app utils.App, method string, route string) bool {
+	if len(app.Allow) > 0 {
+		return permissionsMatch(app.Allow, method, route)
+	}
+
+	if !permissionsMatch(rolePermissions[app.Role], method, route) {
+		return false
+	}
+	if app.Role == utils.RoleOperator && permissionsMatch(operatorDenied, method, route) {
+		return false
+	}
+	return true
+}
+
+// appScopeAllowed checks if the route is allowed for the App limited
+// to some Volumes
+func appScopeAllowed(app utils.App, method string, route string) bool {
+	return !app.VolumeScoped() || !permissionsMatch(scopedDenied, method, route)
+}
+
+// requestApp returns the App of the request verified by VerifyHandler,
+// not available if Auth is disabled
+func requestApp(r *http.Request) (utils.App, bool) {
+	appID, ok := requestAppID(r)
+	if !ok {
+		return utils.App{}, false
+	}
+	return utils.RestApps[appID], true
+}
+
+// volumeAllowed responds with error and returns false if the Volume is
+// not in the scope of the App of request. Used for the Volume names
+// received in request body or query params
+func volumeAllowed(w http.ResponseWriter, r *http.Request, volName string) bool {
+	if app, ok := requestApp(r); ok && !app.VolumeAllowed(volName) {
+		utils.HTTPErrorJSON(w, "Permission denied, Volume "+volName+" is not in the scope of App", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// volumeCreateAllowed responds with error and returns false if the App
+// of request is not allowed to create the Volume
+func volumeCreateAllowed(w http.ResponseWriter, r *http.Request, volName string) bool {
+	if app, ok := requestApp(r); ok && !app.VolumeCreateAllowed(volName) {
+		msg := "Permission denied, App is not allowed to create Volumes"
+		if app.VolumePrefix != "" {
+			msg = "Permission denied, Volume name should start with " + app.VolumePrefix
+		}
+		utils.HTTPErrorJSON(w, msg, http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// AccessHandler is a Middleware to enforce the role or allowed routes of
+// the App verified by VerifyHandler and the Volume scope of the App for
+// the cluster wide routes and the routes with {volName}. Volume names
+// received in request body or query params are checked by handlers.
+// Permission is checked against the matched route template, so the
+// router is used to match the request before serving it. Requests not
+// matching any route are left to the router to respond
+func AccessHandler(router *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		appID, ok := requestAppID(r)
+		if !ok {
+			router.ServeHTTP(w, r)
+			return
+		}
+
+		var match mux.RouteMatch
+		if !router.Match(r, &match) || match.Route == nil {
+			router.ServeHTTP(w, r)
+			return
+		}
+
+		route, err := match.Route.GetPathTemplate()
+		if err != nil {
+			utils.HTTPErrorJSON(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		app := utils.RestApps[appID]
+		if !appAllowed(app, r.Method, route) {
+			utils.HTTPErrorJSON(w, "Permission denied, missing permission: "+r.Method+" "+route, http.StatusForbidden)
+			return
+		}
+
+		if !appScopeAllowed(app, r.Method, route) {
+			utils.HTTPErrorJSON(w, "Permission denied, not allowed for the App limited to Volumes: "+r.Method+" "+route, http.StatusForbidden)
+			return
+		}
+
+		if volName, ok := match.Vars["volName"]; ok && !volumeAllowed(w, r, volName) {
+			return
+		}
+		router.ServeHTTP(w, r)
+	})
+}
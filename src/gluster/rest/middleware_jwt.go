@@ -2,8 +2,10 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"strings"
 	"time"
@@ -14,6 +16,18 @@ import (
 
 var requiredClaims = []string{"iss", "iat", "exp", "qsh"}
 
+type contextKey string
+
+// appIDKey is the request context key of the verified App ID
+const appIDKey contextKey = "appID"
+
+// requestAppID returns the App ID of the request verified by
+// VerifyHandler, not available if Auth is disabled
+func requestAppID(r *http.Request) (string, bool) {
+	appID, ok := r.Context().Value(appIDKey).(string)
+	return appID, ok
+}
+
 // VerifyHandler is a Middleware to handle Claims and JWT verification. JWT is
 // generated at Client side so this Middleware does additional validations
 // compared to simple JWT verification.
@@ -27,6 +41,7 @@ func VerifyHandler(h http.Handler) http.Handler {
 
 		// This flag will be used to expire JWT quickly ignoring exp claim in JWT
 		quickExpire := false
+		queryParams := r.URL.Query()
 
 		// Collect Authorization header, validate if format is different
 		// than "Bearer <TOKEN>"
@@ -39,15 +54,19 @@ func VerifyHandler(h http.Handler) http.Handler {
 				return
 			}
 		} else {
-			// Special case for Websocket URLs, when connected through Javascript
-			// it can't send headers along with the connection. So access token sent
-			// as query parameter token=<TOKEN>. Collect the JWT token and set quickExpire
-			// flag to expire the token quickly irespective of exp set in Claims
+			// Special case for Websocket and Server-Sent Events URLs, when connected
+			// through Javascript it can't send headers along with the connection. So
+			// access token sent as query parameter token=<TOKEN>. Collect the JWT token
+			// and set quickExpire flag to expire the token quickly irespective of exp
+			// set in Claims
 			eventsURL := "/" + utils.RestConfig.APIVersion + utils.RestConfig.EventsURL
-			if r.URL.Path == eventsURL {
-				authHeaderParts[1] = r.URL.Query().Get("token")
+			if r.URL.Path == eventsURL || r.URL.Path == eventsURL+"/stream" {
+				authHeaderParts[1] = queryParams.Get("token")
 				if authHeaderParts[1] != "" {
 					quickExpire = true
+					// Token can't include the hash of itself, so
+					// qsh is generated without the token param
+					queryParams.Del("token")
 				}
 			}
 		}
@@ -57,12 +76,23 @@ func VerifyHandler(h http.Handler) http.Handler {
 		// User inputs, this will be compared with Claims["qsh"]
 		buf := new(bytes.Buffer)
 		buf.ReadFrom(r.Body)
-		qsh := utils.GetQsh(r.Method, r.URL.Path, r.URL.Query().Encode(), buf.String())
+		qsh := utils.GetQsh(r.Method, r.URL.Path, queryParams.Encode(), buf.String())
+
+		// Body is consumed for qsh, restore it for the handlers
+		r.Body = ioutil.NopCloser(bytes.NewReader(buf.Bytes()))
+
+		// glustereventsd signs the token without the qsh claim,
+		// so qsh is not required for Internal APIs
+		internalURL := "/" + utils.RestConfig.APIVersion + utils.RestConfig.ListenURL
+		isInternal := r.URL.Path == internalURL
 
 		// Verify JWT token with additional validations for Claims
 		token, err := jwt.Parse(authHeaderParts[1], func(token *jwt.Token) (interface{}, error) {
 			// Error if required claims are not sent by Client
 			for _, claimName := range requiredClaims {
+				if claimName == "qsh" && isInternal {
+					continue
+				}
 				if _, ok := token.Claims[claimName]; !ok {
 					return nil, fmt.Errorf("Token missing %s Claim", claimName)
 				}
@@ -79,10 +109,10 @@ func VerifyHandler(h http.Handler) http.Handler {
 			}
 
 			// When qsh don't Match
-			if qsh != token.Claims["qsh"] {
+			if !isInternal && qsh != token.Claims["qsh"] {
 				return nil, errors.New("Invalid qsh claim in token")
 			}
-			return []byte(utils.RestApps[token.Claims["iss"].(string)]), nil
+			return []byte(utils.RestApps[token.Claims["iss"].(string)].Secret), nil
 		})
 
 		if err != nil || !token.Valid {
@@ -91,27 +121,28 @@ func VerifyHandler(h http.Handler) http.Handler {
 		}
 
 		// Special Case for Internal APIs Only AppId:gluster can send message
-		internalURL := "/" + utils.RestConfig.APIVersion + utils.RestConfig.ListenURL
-		if token.Claims["iss"] != utils.RestConfig.InternalUser && r.URL.Path == internalURL {
+		if token.Claims["iss"] != utils.RestConfig.InternalUser && isInternal {
 			http.Error(w, http.StatusText(403), 403)
 			return
 		}
 
-		// TODO: Expiry override. If Websocket request then expire the token in 30 secs
+		// Expiry override. If Websocket request then expire the token
+		// in WebsocketExpiry secs from the issued time
 		if quickExpire {
-			now := time.Now().Unix()
-			exp, err := time.Parse(time.UnixDate, fmt.Sprintf("%d", token.Claims["iat"]))
-			if err != nil {
+			iat, ok := token.Claims["iat"].(float64)
+			if !ok {
 				http.Error(w, "Error calculating Expiry", 401)
 				return
 			}
 
-			if now > exp.Add(time.Second*utils.RestConfig.WebsocketExpiry).Unix() {
+			exp := time.Unix(int64(iat), 0).Add(time.Second * utils.RestConfig.WebsocketExpiry)
+			if time.Now().After(exp) {
 				http.Error(w, "Token expired", 401)
 				return
 			}
 		}
 
-		h.ServeHTTP(w, r)
+		appID := token.Claims["iss"].(string)
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), appIDKey, appID)))
 	})
 }
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"gluster/utils"
 )
 
 func AddRoutes(router *mux.Router) {
@@ -14,19 +15,133 @@ func AddRoutes(router *mux.Router) {
 	router.HandleFunc("/v1/volumes/{volName}", VolumeDelete).Methods("DELETE")
 	router.HandleFunc("/v1/volumes/{volName}", VolumeGet).Methods("GET")
 	router.HandleFunc("/v1/volumes", VolumeGet).Methods("GET")
+	router.HandleFunc("/v1/volumes/{volName}/status/{view}", VolumeStatusView).Methods("GET")
 
 	// Volume Options
 	router.HandleFunc("/v1/volumes/{volName}/options", VolumeOptionsGet).Methods("GET")
+	router.HandleFunc("/v1/volumes/{volName}/options/{key}", VolumeOptionGet).Methods("GET")
 	router.HandleFunc("/v1/volumes/{volName}/options", VolumeOptionsSet).Methods("POST")
 	router.HandleFunc("/v1/volumes/{volName}/options", VolumeOptionsReset).Methods("DELETE")
+	router.HandleFunc("/v1/options/catalog", OptionsCatalogGet).Methods("GET")
+	router.HandleFunc("/v1/volumes/{volName}/options/groups/{group}", VolumeOptionsGroupSet).Methods("POST")
+
+	// Options Profiles
+	router.HandleFunc("/v1/options/profiles", ProfilesGet).Methods("GET")
+	router.HandleFunc("/v1/options/profiles/{name}", ProfileSet).Methods("PUT")
+	router.HandleFunc("/v1/options/profiles/{name}", ProfileDelete).Methods("DELETE")
+	router.HandleFunc("/v1/volumes/{volName}/options/profiles/{name}", VolumeProfileDiff).Methods("GET")
+	router.HandleFunc("/v1/volumes/{volName}/options/profiles/{name}", VolumeProfileApply).Methods("POST")
+
+	// Bricks
+	router.HandleFunc("/v1/volumes/{volName}/bricks", VolumeAddBrick).Methods("POST")
+	router.HandleFunc("/v1/volumes/{volName}/bricks", VolumeRemoveBrickStart).Methods("DELETE")
+	router.HandleFunc("/v1/volumes/{volName}/bricks/remove", VolumeRemoveBrickStatus).Methods("GET")
+	router.HandleFunc("/v1/volumes/{volName}/bricks/remove/commit", VolumeRemoveBrickCommit).Methods("POST")
+	router.HandleFunc("/v1/volumes/{volName}/bricks/remove/stop", VolumeRemoveBrickStop).Methods("POST")
+	// Brick name(<HOST>:<PATH>) contains "/", so match rest of the path
+	router.HandleFunc("/v1/volumes/{volName}/bricks/{brick:.+}", VolumeReplaceBrick).Methods("PUT")
+	router.HandleFunc("/v1/volumes/{volName}/bricks/{brick:.+}", VolumeResetBrick).Methods("POST")
+
+	// Rebalance
+	router.HandleFunc("/v1/volumes/{volName}/rebalance", VolumeRebalanceStart).Methods("POST")
+	router.HandleFunc("/v1/volumes/{volName}/rebalance", VolumeRebalanceStatus).Methods("GET")
+	router.HandleFunc("/v1/volumes/{volName}/rebalance", VolumeRebalanceStop).Methods("DELETE")
+
+	// Self Heal
+	router.HandleFunc("/v1/volumes/{volName}/heal", VolumeHealInfo).Methods("GET")
+	router.HandleFunc("/v1/volumes/{volName}/heal/summary", VolumeHealSummary).Methods("GET")
+	router.HandleFunc("/v1/volumes/{volName}/heal", VolumeHeal).Methods("POST")
+	router.HandleFunc("/v1/volumes/{volName}/heal/split-brain", VolumeHealSplitBrain).Methods("POST")
+
+	// Quota
+	router.HandleFunc("/v1/volumes/{volName}/quota", VolumeQuotaEnable).Methods("POST")
+	router.HandleFunc("/v1/volumes/{volName}/quota", VolumeQuotaDisable).Methods("DELETE")
+	router.HandleFunc("/v1/volumes/{volName}/quota/limits", VolumeQuotaLimitsGet).Methods("GET")
+	router.HandleFunc("/v1/volumes/{volName}/quota/limits", VolumeQuotaLimitsSet).Methods("POST")
+	router.HandleFunc("/v1/volumes/{volName}/quota/limits", VolumeQuotaLimitsRemove).Methods("DELETE")
+
+	// Bitrot
+	router.HandleFunc("/v1/volumes/{volName}/bitrot", VolumeBitrotEnable).Methods("POST")
+	router.HandleFunc("/v1/volumes/{volName}/bitrot", VolumeBitrotDisable).Methods("DELETE")
+	router.HandleFunc("/v1/volumes/{volName}/bitrot/scrub", VolumeScrubSet).Methods("POST")
+	router.HandleFunc("/v1/volumes/{volName}/bitrot/scrub", VolumeScrubStatus).Methods("GET")
+	router.HandleFunc("/v1/volumes/{volName}/bitrot/scrub/pause", VolumeScrubPause).Methods("POST")
+	router.HandleFunc("/v1/volumes/{volName}/bitrot/scrub/resume", VolumeScrubResume).Methods("POST")
+
+	// Profile and Top
+	router.HandleFunc("/v1/volumes/{volName}/profile", VolumeProfileStart).Methods("POST")
+	router.HandleFunc("/v1/volumes/{volName}/profile", VolumeProfileStop).Methods("DELETE")
+	router.HandleFunc("/v1/volumes/{volName}/profile", VolumeProfileInfo).Methods("GET")
+	router.HandleFunc("/v1/volumes/{volName}/top/{metric}", VolumeTop).Methods("GET")
+
+	// Geo-replication
+	georepURL := "/v1/volumes/{volName}/georep/{remoteHost}/{remoteVol}"
+	router.HandleFunc(georepURL, GeorepCreate).Methods("PUT")
+	router.HandleFunc(georepURL, GeorepStatus).Methods("GET")
+	router.HandleFunc(georepURL, GeorepDelete).Methods("DELETE")
+	router.HandleFunc(georepURL+"/start", GeorepStart).Methods("POST")
+	router.HandleFunc(georepURL+"/stop", GeorepStop).Methods("POST")
+	router.HandleFunc(georepURL+"/pause", GeorepPause).Methods("POST")
+	router.HandleFunc(georepURL+"/resume", GeorepResume).Methods("POST")
+	router.HandleFunc(georepURL+"/config", GeorepConfigGet).Methods("GET")
+	router.HandleFunc(georepURL+"/config", GeorepConfigSet).Methods("POST")
+	router.HandleFunc(georepURL+"/config", GeorepConfigReset).Methods("DELETE")
+
+	// Snapshot Config and Schedules
+	router.HandleFunc("/v1/snapshots/config", SnapshotConfigGet).Methods("GET")
+	router.HandleFunc("/v1/snapshots/config", SnapshotConfigSet).Methods("POST")
+	router.HandleFunc("/v1/volumes/{volName}/snapshots/config", SnapshotConfigGet).Methods("GET")
+	router.HandleFunc("/v1/volumes/{volName}/snapshots/config", SnapshotConfigSet).Methods("POST")
+	router.HandleFunc("/v1/snapshots/schedules", SnapScheduleGet).Methods("GET")
+	router.HandleFunc("/v1/snapshots/schedules/{name}", SnapScheduleSet).Methods("PUT")
+	router.HandleFunc("/v1/snapshots/schedules/{name}", SnapScheduleDelete).Methods("DELETE")
+	router.HandleFunc("/v1/snapshots/schedules/{name}/runs", SnapScheduleRuns).Methods("GET")
+
+	// Snapshots
+	router.HandleFunc("/v1/snapshots/{snapName}", SnapshotCreate).Methods("PUT")
+	router.HandleFunc("/v1/snapshots/{snapName}/activate", SnapshotActivate).Methods("POST")
+	router.HandleFunc("/v1/snapshots/{snapName}/deactivate", SnapshotDeactivate).Methods("POST")
+	router.HandleFunc("/v1/snapshots/{snapName}/restore", SnapshotRestore).Methods("POST")
+	router.HandleFunc("/v1/snapshots/{snapName}/clone", SnapshotClone).Methods("POST")
+	router.HandleFunc("/v1/snapshots/{snapName}", SnapshotDelete).Methods("DELETE")
+	router.HandleFunc("/v1/snapshots/{snapName}", SnapshotGet).Methods("GET")
+	router.HandleFunc("/v1/snapshots", SnapshotGet).Methods("GET")
 
 	// Peers
 	router.HandleFunc("/v1/peers", PeersAdd).Methods("POST")
 	router.HandleFunc("/v1/peers", PeersRemove).Methods("DELETE")
 	router.HandleFunc("/v1/peers", PeersGet).Methods("GET")
 
+	// Cluster Options and op-version
+	router.HandleFunc("/v1/cluster/options", ClusterOptionsGet).Methods("GET")
+	router.HandleFunc("/v1/cluster/options", ClusterOptionsSet).Methods("POST")
+	router.HandleFunc("/v1/cluster/options", ClusterOptionsReset).Methods("DELETE")
+	router.HandleFunc("/v1/cluster/op-version", ClusterOpVersionGet).Methods("GET")
+	router.HandleFunc("/v1/cluster/op-version", ClusterOpVersionSet).Methods("POST")
+
+	// Events, URL is configurable since VerifyHandler allows token as
+	// query parameter only for this URL and its stream URL
+	eventsURL := "/" + utils.RestConfig.APIVersion + utils.RestConfig.EventsURL
+	router.HandleFunc(eventsURL, EventsWebsocket).Methods("GET").MatcherFunc(isWebsocketRequest)
+	router.HandleFunc(eventsURL+"/stream", EventsStream).Methods("GET")
+	router.HandleFunc(eventsURL, EventsHistoryGet).Methods("GET")
+
+	// Webhooks
+	router.HandleFunc("/v1/webhooks", WebhookCreate).Methods("POST")
+	router.HandleFunc("/v1/webhooks", WebhooksGet).Methods("GET")
+	router.HandleFunc("/v1/webhooks/{id}", WebhookUpdate).Methods("PUT")
+	router.HandleFunc("/v1/webhooks/{id}", WebhookGet).Methods("GET")
+	router.HandleFunc("/v1/webhooks/{id}", WebhookDelete).Methods("DELETE")
+	router.HandleFunc("/v1/webhooks/{id}/deliveries", WebhookDeliveriesGet).Methods("GET")
+	router.HandleFunc("/v1/webhooks/{id}/dead-letters", WebhookDeadLettersGet).Methods("GET")
+
+	// Internal API to receive Events from glustereventsd
+	listenURL := "/" + utils.RestConfig.APIVersion + utils.RestConfig.ListenURL
+	router.HandleFunc(listenURL, EventsListen).Methods("POST")
+
 	http.Handle("/",
 		RestLoggingHandler(
 			SetApplicationHeaderJSON(
-				VerifyHandler(router))))
+				VerifyHandler(
+					AccessHandler(router)))))
 }
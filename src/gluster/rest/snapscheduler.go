@@ -0,0 +1,161 @@
+package main
+
+import (
+	"time"
+
+	"github.com/robfig/cron"
+	"gluster/cli"
+	"gluster/utils"
+)
+
+// Number of runs remembered for each Snapshot schedule
+const snapScheduleRunsMax = 20
+
+// Snapshot schedules are executed only in one node, connected peer
+// node with lowest UUID runs the schedules
+func isSnapSchedulerNode() bool {
+	peers, err := cli.PoolList()
+	if err != nil {
+		utils.Logger.Error("Unable to get Peers list for Snapshot scheduler: ", err)
+		return false
+	}
+
+	schedulerNode := ""
+	for _, p := range peers {
+		if p.Connected != 1 {
+			continue
+		}
+		if schedulerNode == "" || p.ID < schedulerNode {
+			schedulerNode = p.ID
+		}
+	}
+	return schedulerNode != "" && schedulerNode == utils.MyUUID
+}
+
+// snapScheduleRun creates the Snapshot and deletes the older Snapshots
+// created by the schedule exceeding the retention count. snapshots is
+// the list of Snapshots created by the schedule in creation order,
+// updated list is returned. Snapshots created manually are never
+// deleted even if the name matches.
+func snapScheduleRun(s utils.SnapSchedule, snapshots []string) (utils.SnapScheduleRun, []string) {
+	run := utils.SnapScheduleRun{Schedule: s.Name, Volume: s.Volume, StartTime: time.Now()}
+	opts := cli.SnapCreateOptions{Description: "Scheduled Snapshot " + s.Name}
+	name, err := cli.SnapshotCreate(s.Name, s.Volume, opts)
+	if err != nil {
+		run.Error = err.Error()
+		return run, snapshots
+	}
+	run.Snapshot = name
+	snapshots = append(snapshots, name)
+
+	if s.Retention > 0 {
+		existing, err := cli.SnapshotList(s.Volume)
+		if err != nil {
+			run.Error = err.Error()
+			return run, snapshots
+		}
+
+		// Forget the Snapshots which are deleted outside the scheduler
+		var retained []string
+		for _, snap := range snapshots {
+			if utils.StringInList(snap, existing) {
+				retained = append(retained, snap)
+			}
+		}
+		snapshots = retained
+
+		for len(snapshots) > s.Retention {
+			err := cli.SnapshotDelete(snapshots[0])
+			if err != nil {
+				run.Error = err.Error()
+				return run, snapshots
+			}
+			run.Deleted = append(run.Deleted, snapshots[0])
+			snapshots = snapshots[1:]
+		}
+	}
+
+	run.Ok = true
+	return run, snapshots
+}
+
+// snapScheduleExecute runs the Snapshot schedule and saves the run and
+// the Snapshots created by the schedule in history. Snapshot commands
+// are run before updating the history so that the history is not
+// locked during the run. Only the scheduler updates the Snapshots of
+// a schedule, so reading the history before the run is safe
+func snapScheduleExecute(s utils.SnapSchedule) {
+	history, err := utils.LoadSnapSchedulesHistory()
+	if err != nil {
+		utils.Logger.Error("Unable to load Snapshot schedule history ", s.Name, ": ", err)
+		return
+	}
+
+	run, snapshots := snapScheduleRun(s, history[s.Name].Snapshots)
+	if !run.Ok {
+		utils.Logger.Error("Scheduled Snapshot failed, schedule: ", run.Schedule,
+			" volume: ", run.Volume, " error: ", run.Error)
+	}
+
+	err = utils.UpdateSnapSchedulesHistory(func(history utils.SnapSchedulesHistory) error {
+		// History is not saved if the schedule is deleted during the run
+		schedules, err := utils.LoadSnapSchedules()
+		if err != nil {
+			return err
+		}
+		if _, ok := schedules[s.Name]; !ok {
+			return nil
+		}
+
+		h := history[s.Name]
+		h.Snapshots = snapshots
+		h.Runs = append(h.Runs, run)
+		if len(h.Runs) > snapScheduleRunsMax {
+			h.Runs = h.Runs[len(h.Runs)-snapScheduleRunsMax:]
+		}
+		history[s.Name] = h
+		return nil
+	})
+	if err != nil {
+		utils.Logger.Error("Unable to save Snapshot schedule history ", s.Name, ": ", err)
+	}
+}
+
+// SnapSchedulerStart starts the Snapshot scheduler, schedules are checked
+// every minute and the Snapshots which are due are created
+func SnapSchedulerStart() {
+	go func() {
+		last := time.Now().Truncate(time.Minute)
+		for {
+			next := last.Add(time.Minute)
+			time.Sleep(next.Sub(time.Now()))
+
+			schedules, err := utils.LoadSnapSchedules()
+			if err != nil {
+				utils.Logger.Error("Unable to load Snapshot schedules: ", err)
+				last = next
+				continue
+			}
+
+			var due []utils.SnapSchedule
+			for _, s := range schedules {
+				sched, err := cron.ParseStandard(s.Schedule)
+				if err != nil {
+					utils.Logger.Error("Invalid Snapshot schedule ", s.Name, ": ", err)
+					continue
+				}
+				if !sched.Next(last).After(next) {
+					due = append(due, s)
+				}
+			}
+			last = next
+
+			if len(due) == 0 || !isSnapSchedulerNode() {
+				continue
+			}
+			for _, s := range due {
+				snapScheduleExecute(s)
+			}
+		}
+	}()
+}
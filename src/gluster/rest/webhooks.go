@@ -0,0 +1,392 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"gluster/utils"
+)
+
+const (
+	// Number of delivery attempts before the Event is moved to the
+	// dead-letter list
+	webhookMaxAttempts = 5
+
+	// Delay before the first retry, doubled for every retry
+	webhookRetryDelay = 2 * time.Second
+
+	webhookTimeout = 10 * time.Second
+
+	// Number of delivery attempts and dead letters remembered for
+	// each webhook
+	webhookDeliveriesMax  = 50
+	webhookDeadLettersMax = 100
+)
+
+// WebhookDelivery - Result of an attempt to deliver the Event
+type WebhookDelivery struct {
+	ID         string    `json:"id"`
+	EventSeq   uint64    `json:"event_seq"`
+	Event      string    `json:"event"`
+	Attempt    int       `json:"attempt"`
+	Time       time.Time `json:"time"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Ok         bool      `json:"ok"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// WebhookDeadLetter - Event which could not be delivered after all the
+// attempts
+type WebhookDeadLetter struct {
+	ID        string    `json:"id"`
+	Event     Event     `json:"event"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error"`
+	Time      time.Time `json:"time"`
+}
+
+// Deliveries and dead letters are saved in Events store, in a nested
+// bucket for each webhook
+var (
+	webhookDeliveriesBucket  = []byte("webhook_deliveries")
+	webhookDeadLettersBucket = []byte("webhook_dead_letters")
+)
+
+// webhookWorkers - Wake up channel of the delivery worker of each
+// webhook. Workers read the Events by sequence number, so the Events
+// are delivered in the order they were received and none of them are
+// missed if a worker is slow
+var webhookWorkers = struct {
+	sync.Mutex
+	wakeups map[string]chan struct{}
+}{
+	wakeups: make(map[string]chan struct{}),
+}
+
+var webhookClient = &http.Client{Timeout: webhookTimeout}
+
+// webhookRecordAdd saves the record in the webhook's bucket and deletes
+// the oldest records if number of records exceeds max
+func webhookRecordAdd(bucket []byte, webhookID string, max int, record interface{}) error {
+	if eventsDB == nil {
+		return errEventsStoreNotAvailable
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return eventsDB.Update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket(bucket).CreateBucketIfNotExists([]byte(webhookID))
+		if err != nil {
+			return err
+		}
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		err = b.Put(seqKey(id), data)
+		if err != nil {
+			return err
+		}
+
+		// Stats of the bucket do not include the changes of this
+		// transaction, so the records are counted using cursor
+		var keys [][]byte
+		c := b.Cursor()
+		for k, _ := c.Last(); k != nil; k, _ = c.Prev() {
+			if max--; max < 0 {
+				keys = append(keys, append([]byte{}, k...))
+			}
+		}
+		for _, k := range keys {
+			err := b.Delete(k)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// webhookRecordsGet calls fn with each record of the webhook, in the
+// order they were saved
+func webhookRecordsGet(bucket []byte, webhookID string, fn func(data []byte) error) error {
+	if eventsDB == nil {
+		return errEventsStoreNotAvailable
+	}
+
+	return eventsDB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket).Bucket([]byte(webhookID))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			return fn(v)
+		})
+	})
+}
+
+func webhookDeliveryAdd(webhookID string, d WebhookDelivery) {
+	err := webhookRecordAdd(webhookDeliveriesBucket, webhookID, webhookDeliveriesMax, d)
+	if err != nil && err != errEventsStoreNotAvailable {
+		utils.Logger.Error("Unable to save delivery of webhook ", webhookID, ": ", err)
+	}
+}
+
+func webhookDeadLetterAdd(webhookID string, d WebhookDeadLetter) {
+	utils.Logger.Error("Webhook ", webhookID, " failed to deliver Event ", d.Event.Seq, ": ", d.LastError)
+
+	err := webhookRecordAdd(webhookDeadLettersBucket, webhookID, webhookDeadLettersMax, d)
+	if err != nil && err != errEventsStoreNotAvailable {
+		utils.Logger.Error("Unable to save dead letter of webhook ", webhookID, ": ", err)
+	}
+}
+
+// webhookDeliveriesGet returns the recent delivery attempts, latest first
+func webhookDeliveriesGet(webhookID string) ([]WebhookDelivery, error) {
+	attempts := []WebhookDelivery{}
+	err := webhookRecordsGet(webhookDeliveriesBucket, webhookID, func(data []byte) error {
+		var d WebhookDelivery
+		err := json.Unmarshal(data, &d)
+		if err != nil {
+			return err
+		}
+		attempts = append([]WebhookDelivery{d}, attempts...)
+		return nil
+	})
+	return attempts, err
+}
+
+func webhookDeadLettersGet(webhookID string) ([]WebhookDeadLetter, error) {
+	letters := []WebhookDeadLetter{}
+	err := webhookRecordsGet(webhookDeadLettersBucket, webhookID, func(data []byte) error {
+		var d WebhookDeadLetter
+		err := json.Unmarshal(data, &d)
+		if err != nil {
+			return err
+		}
+		letters = append(letters, d)
+		return nil
+	})
+	return letters, err
+}
+
+func webhookDeliveriesDelete(webhookID string) error {
+	if eventsDB == nil {
+		return errEventsStoreNotAvailable
+	}
+
+	return eventsDB.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{webhookDeliveriesBucket, webhookDeadLettersBucket} {
+			err := tx.Bucket(bucket).DeleteBucket([]byte(webhookID))
+			if err != nil && err != bolt.ErrBucketNotFound {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func randomID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// webhookSignature is HMAC SHA256 of "<timestamp>.<payload>", timestamp
+// is included so that the receivers can reject the replayed requests
+func webhookSignature(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func webhookPost(webhook utils.Webhook, deliveryID string, e Event, payload []byte) (int, error) {
+	req, err := http.NewRequest("POST", webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gluster-Event", e.Event)
+	req.Header.Set("X-Gluster-Delivery", deliveryID)
+	req.Header.Set("X-Gluster-Timestamp", timestamp)
+	if webhook.Secret != "" {
+		req.Header.Set("X-Gluster-Signature", webhookSignature(webhook.Secret, timestamp, payload))
+	}
+	if webhook.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+webhook.BearerToken)
+	}
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("Unexpected response status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// webhookLatest returns the latest details of the webhook, false if the
+// webhook is deleted. Webhook is assumed to exist if the webhooks file
+// can not be read
+func webhookLatest(webhook utils.Webhook) (utils.Webhook, bool) {
+	webhooks, err := utils.LoadWebhooks()
+	if err != nil {
+		utils.Logger.Error("Unable to load webhooks: ", err)
+		return webhook, true
+	}
+	w, ok := webhooks[webhook.ID]
+	return w, ok
+}
+
+// webhookDeliver delivers the Event to the webhook, retries with
+// exponential backoff and moves the Event to dead-letter list if all
+// the attempts fail. Webhook is loaded again before every attempt,
+// delivery is stopped if the webhook is deleted
+func webhookDeliver(webhook utils.Webhook, e Event) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		utils.Logger.Error("Unable to encode Event ", e.Seq, ": ", err)
+		return
+	}
+
+	deliveryID := randomID()
+	delay := webhookRetryDelay
+	for attempt := 1; attempt <= webhookMaxAttempts; attempt++ {
+		current, ok := webhookLatest(webhook)
+		if !ok {
+			return
+		}
+		webhook = current
+
+		statusCode, err := webhookPost(webhook, deliveryID, e, payload)
+		d := WebhookDelivery{
+			ID:         deliveryID,
+			EventSeq:   e.Seq,
+			Event:      e.Event,
+			Attempt:    attempt,
+			Time:       time.Now(),
+			StatusCode: statusCode,
+			Ok:         err == nil,
+		}
+		if err != nil {
+			d.Error = err.Error()
+		}
+		webhookDeliveryAdd(webhook.ID, d)
+
+		if err == nil {
+			return
+		}
+
+		if attempt == webhookMaxAttempts {
+			webhookDeadLetterAdd(webhook.ID, WebhookDeadLetter{
+				ID:        deliveryID,
+				Event:     e,
+				Attempts:  attempt,
+				LastError: d.Error,
+				Time:      d.Time,
+			})
+			return
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// webhookWorker delivers the Events published after lastSeq to the
+// webhook one by one, retries of an Event delay the Events after it.
+// Worker exits if the webhook is deleted
+func webhookWorker(webhookID string, lastSeq uint64, wakeup chan struct{}) {
+	for range wakeup {
+		for {
+			events, err := hub.Since(lastSeq)
+			if err != nil && err != errEventsStoreNotAvailable {
+				utils.Logger.Error("Unable to read Events from Events store: ", err)
+			}
+			if len(events) == 0 {
+				break
+			}
+			if events[0].Seq != lastSeq+1 {
+				utils.Logger.Error("Webhook ", webhookID, " missed Events ", lastSeq+1, " to ", events[0].Seq-1)
+			}
+
+			webhook, ok := webhookLatest(utils.Webhook{ID: webhookID})
+			if !ok {
+				return
+			}
+			filter := eventFilter{Types: webhook.Events, Volumes: webhook.Volumes}
+			for _, e := range events {
+				if filter.Match(e) {
+					webhookDeliver(webhook, e)
+				}
+				lastSeq = e.Seq
+			}
+		}
+	}
+}
+
+// webhookWorkersNotify starts the workers of new webhooks, stops the
+// workers of deleted webhooks and wakes up all the workers. Workers of
+// new webhooks deliver the Events after lastSeq
+func webhookWorkersNotify(webhooks utils.Webhooks, lastSeq uint64) {
+	webhookWorkers.Lock()
+	defer webhookWorkers.Unlock()
+	for id, wakeup := range webhookWorkers.wakeups {
+		if _, ok := webhooks[id]; !ok {
+			close(wakeup)
+			delete(webhookWorkers.wakeups, id)
+		}
+	}
+
+	for id := range webhooks {
+		wakeup, ok := webhookWorkers.wakeups[id]
+		if !ok {
+			wakeup = make(chan struct{}, 1)
+			webhookWorkers.wakeups[id] = wakeup
+			go webhookWorker(id, lastSeq, wakeup)
+		}
+
+		select {
+		case wakeup <- struct{}{}:
+		default:
+			// Worker is already notified
+		}
+	}
+}
+
+// WebhooksStart starts delivering the received Events to the webhook
+// subscriptions. Subscription is used only to wake up the workers, so
+// the Events dropped for this subscriber are also delivered. Webhooks
+// are loaded for every Event since the webhooks file can be updated
+// from any node
+func WebhooksStart() {
+	events := hub.Subscribe()
+	go func() {
+		for e := range events {
+			webhooks, err := utils.LoadWebhooks()
+			if err != nil {
+				utils.Logger.Error("Unable to load webhooks: ", err)
+				continue
+			}
+			webhookWorkersNotify(webhooks, e.Seq-1)
+		}
+	}()
+}
@@ -5,10 +5,77 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"strings"
 )
 
-// Apps to store the applications ID:Secret
-type Apps map[string]string
+// Roles of the applications
+const (
+	RoleViewer   = "viewer"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+// App to store the application details. Allow is the list of allowed
+// "<METHOD> <ROUTE>" patterns, if specified Role is not used. Volumes
+// and VolumePrefix limit the Volumes the App can see and manage, App
+// is not limited if both are empty
+type App struct {
+	Secret       string   `json:"secret"`
+	Role         string   `json:"role,omitempty"`
+	Allow        []string `json:"allow,omitempty"`
+	Volumes      []string `json:"volumes,omitempty"`
+	VolumePrefix string   `json:"volume_prefix,omitempty"`
+}
+
+// VolumeScoped returns true if the App is limited to some Volumes
+func (a App) VolumeScoped() bool {
+	return len(a.Volumes) > 0 || a.VolumePrefix != ""
+}
+
+// VolumeAllowed checks if the Volume is in the scope of App
+func (a App) VolumeAllowed(volname string) bool {
+	if !a.VolumeScoped() {
+		return true
+	}
+	if a.VolumePrefix != "" && strings.HasPrefix(volname, a.VolumePrefix) {
+		return true
+	}
+	return StringInList(volname, a.Volumes)
+}
+
+// VolumeCreateAllowed checks if the App can create the Volume, only
+// the names with App's Volume prefix are allowed for the scoped Apps
+func (a App) VolumeCreateAllowed(volname string) bool {
+	if !a.VolumeScoped() {
+		return true
+	}
+	return a.VolumePrefix != "" && strings.HasPrefix(volname, a.VolumePrefix)
+}
+
+// UnmarshalJSON accepts the older format of apps file where only
+// the secret was stored, such apps will have admin role
+func (a *App) UnmarshalJSON(data []byte) error {
+	var secret string
+	if err := json.Unmarshal(data, &secret); err == nil {
+		*a = App{Secret: secret, Role: RoleAdmin}
+		return nil
+	}
+
+	// Separate type to avoid calling UnmarshalJSON recursively
+	type app App
+	var out app
+	if err := json.Unmarshal(data, &out); err != nil {
+		return err
+	}
+	*a = App(out)
+	if a.Role == "" && len(a.Allow) == 0 {
+		a.Role = RoleAdmin
+	}
+	return nil
+}
+
+// Apps to store the applications ID:App
+type Apps map[string]App
 
 func loadApps(fail bool) {
 	data, err := ioutil.ReadFile(RestConfig.AppsFile)
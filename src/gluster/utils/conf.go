@@ -4,27 +4,82 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"log"
+	"path/filepath"
 	"time"
 )
 
+// Defaults of the configurations which may be missing in the config
+// file of older version
+const (
+	defaultEventsRetentionDays  = 30
+	defaultEventsRetentionCount = 100000
+	defaultEventsRetentionBytes = 100 * 1024 * 1024
+)
+
 // Config to store all configurations related to REST
 type Config struct {
-	AuthEnabled     bool          `json:"auth_enabled"`
-	Port            int           `json:"port"`
-	UseHTTPS        bool          `json:"https"`
-	Csr             string        `json:"csr"`
-	Key             string        `json:"key"`
-	AppsFile        string        `json:"apps_file"`
-	AccessLogFile   string        `json:"access_log_file"`
-	EventsSockFile  string        `json:"events_sock_file"`
-	InternalUser    string        `json:"internal_user"`
-	ListenURL       string        `json:"listen_url"`
-	APIVersion      string        `json:"api_version"`
-	EventsURL       string        `json:"events_url"`
-	WebsocketExpiry time.Duration `json:"websocket_expiry"`
+	AuthEnabled              bool          `json:"auth_enabled"`
+	Port                     int           `json:"port"`
+	UseHTTPS                 bool          `json:"https"`
+	Csr                      string        `json:"csr"`
+	Key                      string        `json:"key"`
+	GlusterdWorkdir          string        `json:"glusterd_workdir"`
+	AppsFile                 string        `json:"apps_file"`
+	SnapSchedulesFile        string        `json:"snap_schedules_file"`
+	SnapSchedulesHistoryFile string        `json:"snap_schedules_history_file"`
+	ProfilesFile             string        `json:"profiles_file"`
+	WebhooksFile             string        `json:"webhooks_file"`
+	AccessLogFile            string        `json:"access_log_file"`
+	EventsSockFile           string        `json:"events_sock_file"`
+	InternalUser             string        `json:"internal_user"`
+	ListenURL                string        `json:"listen_url"`
+	APIVersion               string        `json:"api_version"`
+	EventsURL                string        `json:"events_url"`
+	WebsocketExpiry          time.Duration `json:"websocket_expiry"`
+	EventsDBFile             string        `json:"events_db_file"`
+	EventsRetentionDays      int           `json:"events_retention_days"`
+	EventsRetentionCount     int           `json:"events_retention_count"`
+	EventsRetentionBytes     int64         `json:"events_retention_bytes"`
+}
+
+// setConfigDefaults sets the default values before loading the config
+// files, values from config files override them. Zero value disables
+// the retention, so the default is not set after loading
+func setConfigDefaults() {
+	RestConfig.EventsRetentionDays = defaultEventsRetentionDays
+	RestConfig.EventsRetentionCount = defaultEventsRetentionCount
+	RestConfig.EventsRetentionBytes = defaultEventsRetentionBytes
+}
+
+// setConfigPathDefaults sets the file paths which are not set in the
+// config files, paths are derived from glusterd workdir
+func setConfigPathDefaults() {
+	if RestConfig.GlusterdWorkdir == "" {
+		return
+	}
+
+	restDir := filepath.Join(RestConfig.GlusterdWorkdir, "rest")
+	paths := []struct {
+		value *string
+		name  string
+	}{
+		{&RestConfig.SnapSchedulesFile, "snap_schedules.json"},
+		{&RestConfig.SnapSchedulesHistoryFile, "snap_schedules_history.json"},
+		{&RestConfig.ProfilesFile, "profiles.json"},
+		{&RestConfig.WebhooksFile, "webhooks.json"},
+		{&RestConfig.EventsDBFile, "events.db"},
+	}
+	for _, p := range paths {
+		if *p.value == "" {
+			*p.value = filepath.Join(restDir, p.name)
+		}
+	}
 }
 
 func loadConfig(defaultConfigFile string, customConfigFile string, fail bool) {
+	setConfigDefaults()
+	defer setConfigPathDefaults()
+
 	data, err := ioutil.ReadFile(defaultConfigFile)
 	if err != nil && fail {
 		Logger.Fatal("No conf file")
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// readJSONFile reads the JSON file from glusterd workdir, out is not
+// modified if the file does not exist
+func readJSONFile(path string, out interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// writeJSONFile atomically writes the JSON file to glusterd workdir and
+// syncs it to all the peer nodes
+func writeJSONFile(path string, in interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	tmpFile := path + ".tmp"
+	err = ioutil.WriteFile(tmpFile, data, 0600)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmpFile, path)
+	if err != nil {
+		return err
+	}
+
+	return SyncFile(path)
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"sync"
+)
+
+// OptionsProfile to store the custom set of Volume options, which can
+// be applied to any Volume
+type OptionsProfile struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Options     map[string]string `json:"options"`
+}
+
+// OptionsProfiles to store the custom profiles, Name:OptionsProfile
+type OptionsProfiles map[string]OptionsProfile
+
+// profilesLock serializes read-modify-write of profiles file
+var profilesLock sync.Mutex
+
+// LoadOptionsProfiles reads the custom Volume options profiles from
+// profiles file
+func LoadOptionsProfiles() (OptionsProfiles, error) {
+	profiles := make(OptionsProfiles)
+	err := readJSONFile(RestConfig.ProfilesFile, &profiles)
+	return profiles, err
+}
+
+// UpdateOptionsProfiles loads the profiles, applies the changes using
+// given func and saves the profiles file. Updated profiles file is
+// synced to all the peer nodes
+func UpdateOptionsProfiles(update func(OptionsProfiles) error) error {
+	profilesLock.Lock()
+	defer profilesLock.Unlock()
+
+	profiles, err := LoadOptionsProfiles()
+	if err != nil {
+		return err
+	}
+
+	err = update(profiles)
+	if err != nil {
+		return err
+	}
+
+	return writeJSONFile(RestConfig.ProfilesFile, profiles)
+}
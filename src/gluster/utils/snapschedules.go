@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// SnapSchedule to store the Snapshot schedule of a Volume. Schedule is a
+// cron expression, Retention is the number of scheduled Snapshots to
+// retain. All scheduled Snapshots are retained if Retention is 0.
+type SnapSchedule struct {
+	Name      string `json:"name"`
+	Volume    string `json:"volume"`
+	Schedule  string `json:"schedule"`
+	Retention int    `json:"retention"`
+}
+
+// SnapSchedules to store the Snapshot schedules, Name:SnapSchedule
+type SnapSchedules map[string]SnapSchedule
+
+// snapSchedulesLock serializes read-modify-write of schedules file
+var snapSchedulesLock sync.Mutex
+
+// LoadSnapSchedules reads the Snapshot schedules from schedules file.
+// Schedules file is read every time since it may be updated by any
+// peer node.
+func LoadSnapSchedules() (SnapSchedules, error) {
+	schedules := make(SnapSchedules)
+	err := readJSONFile(RestConfig.SnapSchedulesFile, &schedules)
+	return schedules, err
+}
+
+// UpdateSnapSchedules loads the Snapshot schedules, applies the changes
+// using given func and saves the schedules file. Updated schedules file
+// is synced to all the peer nodes
+func UpdateSnapSchedules(update func(SnapSchedules) error) error {
+	snapSchedulesLock.Lock()
+	defer snapSchedulesLock.Unlock()
+
+	schedules, err := LoadSnapSchedules()
+	if err != nil {
+		return err
+	}
+
+	err = update(schedules)
+	if err != nil {
+		return err
+	}
+
+	return writeJSONFile(RestConfig.SnapSchedulesFile, schedules)
+}
+
+// SnapScheduleRun object, result of a scheduled Snapshot run
+type SnapScheduleRun struct {
+	Schedule  string    `json:"schedule"`
+	Volume    string    `json:"volume"`
+	Snapshot  string    `json:"snapshot,omitempty"`
+	StartTime time.Time `json:"start_time"`
+	Ok        bool      `json:"ok"`
+	Error     string    `json:"error,omitempty"`
+	Deleted   []string  `json:"deleted,omitempty"`
+}
+
+// SnapScheduleHistory to store the recent runs of a Snapshot schedule
+// and the names of Snapshots created by the schedule. Only these
+// Snapshots are deleted as per retention.
+type SnapScheduleHistory struct {
+	Runs      []SnapScheduleRun `json:"runs"`
+	Snapshots []string          `json:"snapshots"`
+}
+
+// SnapSchedulesHistory to store the history of Snapshot schedules,
+// Name:SnapScheduleHistory
+type SnapSchedulesHistory map[string]SnapScheduleHistory
+
+// snapSchedulesHistoryLock serializes read-modify-write of history file
+var snapSchedulesHistoryLock sync.Mutex
+
+// LoadSnapSchedulesHistory reads the Snapshot schedules history from
+// history file. History file is synced to all the peer nodes, so it
+// is available even if the node running the schedules is changed.
+func LoadSnapSchedulesHistory() (SnapSchedulesHistory, error) {
+	history := make(SnapSchedulesHistory)
+	err := readJSONFile(RestConfig.SnapSchedulesHistoryFile, &history)
+	return history, err
+}
+
+// UpdateSnapSchedulesHistory loads the Snapshot schedules history,
+// applies the changes using given func and saves the history file.
+// Updated history file is synced to all the peer nodes
+func UpdateSnapSchedulesHistory(update func(SnapSchedulesHistory) error) error {
+	snapSchedulesHistoryLock.Lock()
+	defer snapSchedulesHistoryLock.Unlock()
+
+	history, err := LoadSnapSchedulesHistory()
+	if err != nil {
+		return err
+	}
+
+	err = update(history)
+	if err != nil {
+		return err
+	}
+
+	return writeJSONFile(RestConfig.SnapSchedulesHistoryFile, history)
+}
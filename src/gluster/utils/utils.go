@@ -15,6 +15,7 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/dgrijalva/jwt-go"
+	"gluster/cli"
 )
 
 var (
@@ -38,7 +39,8 @@ type CmdResponse struct {
 }
 
 type errorResponse struct {
-	Message string `json:"message"`
+	Message string            `json:"message"`
+	Errors  map[string]string `json:"errors,omitempty"`
 }
 
 // HTTPErrorJSON is a utility function to write error in JSON format
@@ -50,11 +52,26 @@ func HTTPErrorJSON(w http.ResponseWriter, err string, code int) {
 	w.Write(j)
 }
 
+// HTTPFieldErrorsJSON is a utility function to write error in JSON format
+// along with the errors of each field to HTTP ResponseWriter
+func HTTPFieldErrorsJSON(w http.ResponseWriter, err string, fieldErrors map[string]string, code int) {
+	msg := errorResponse{Message: err, Errors: fieldErrors}
+	j, _ := json.Marshal(msg)
+	w.WriteHeader(code)
+	w.Write(j)
+}
+
 // HTTPOutJSON is a utility func to write JSON output to given HTTP
 // ResponseWriter
 func HTTPOutJSON(w http.ResponseWriter, out interface{}) {
+	HTTPOutJSONStatus(w, out, http.StatusOK)
+}
+
+// HTTPOutJSONStatus is a utility func to write JSON output to given HTTP
+// ResponseWriter with the given status code
+func HTTPOutJSONStatus(w http.ResponseWriter, out interface{}, code int) {
 	j, _ := json.Marshal(out)
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(code)
 	w.Write(j)
 }
 
@@ -103,6 +120,24 @@ func Execute(cmd []string) CmdResponse {
 	return out
 }
 
+// StringInList is a helper func to check if the string is present
+// in the list
+func StringInList(s string, list []string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// SyncFile is a helper func to copy a file from glusterd workdir of
+// current node to all the peer nodes
+func SyncFile(path string) error {
+	cmd := []string{"system::", "copy", "file", strings.TrimPrefix(path, RestConfig.GlusterdWorkdir)}
+	return cli.ExecuteCmd(cmd)
+}
+
 // GetQsh will generate qsh by using Method, Path and Data.
 func GetQsh(method string, path string, queryParams string, data string) string {
 	qshData := method + "\n" + path
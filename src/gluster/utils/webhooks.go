@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// Webhook to store the outbound webhook subscription. Events and
+// Volumes filter the Events delivered, empty list means all
+type Webhook struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Events      []string  `json:"events"`
+	Volumes     []string  `json:"volumes"`
+	BearerToken string    `json:"bearer_token,omitempty"`
+	Secret      string    `json:"secret,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Webhooks to store the webhook subscriptions, ID:Webhook
+type Webhooks map[string]Webhook
+
+// webhooksLock serializes read-modify-write of webhooks file
+var webhooksLock sync.Mutex
+
+// LoadWebhooks reads the webhook subscriptions from webhooks file
+func LoadWebhooks() (Webhooks, error) {
+	webhooks := make(Webhooks)
+	err := readJSONFile(RestConfig.WebhooksFile, &webhooks)
+	return webhooks, err
+}
+
+// UpdateWebhooks loads the webhooks, applies the changes using given
+// func and saves the webhooks file. Updated webhooks file is synced
+// to all the peer nodes
+func UpdateWebhooks(update func(Webhooks) error) error {
+	webhooksLock.Lock()
+	defer webhooksLock.Unlock()
+
+	webhooks, err := LoadWebhooks()
+	if err != nil {
+		return err
+	}
+
+	err = update(webhooks)
+	if err != nil {
+		return err
+	}
+
+	return writeJSONFile(RestConfig.WebhooksFile, webhooks)
+}